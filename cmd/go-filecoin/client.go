@@ -2,12 +2,10 @@ package commands
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-fil-markets/storagemarket"
 	"github.com/filecoin-project/go-filecoin/internal/pkg/types"
-	"github.com/filecoin-project/specs-actors/actors/abi"
 	"github.com/pkg/errors"
 
 	"github.com/ipfs/go-cid"
@@ -160,12 +158,12 @@ be 2, 1 hour would be 120, and 1 day would be 2880.
 			PeerID:     peerID,
 		}
 
-		start, err := strconv.ParseUint(req.Arguments[2], 10, 64)
+		start, err := types.ParseChainEpoch(req.Arguments[2])
 		if err != nil {
 			return errors.Wrap(err, "could not parse deal start")
 		}
 
-		end, err := strconv.ParseUint(req.Arguments[3], 10, 64)
+		end, err := types.ParseChainEpoch(req.Arguments[3])
 		if err != nil {
 			return errors.Wrap(err, "could not parse deal end")
 		}
@@ -185,8 +183,8 @@ be 2, 1 hour would be 120, and 1 day would be 2880.
 			addr,
 			providerInfo,
 			data,
-			abi.ChainEpoch(start),
-			abi.ChainEpoch(end),
+			start,
+			end,
 			price,
 			collateral,
 			status.SealProofType,
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"os"
 	"syscall"
+	"time"
 
 	cmdkit "github.com/ipfs/go-ipfs-cmdkit"
 	cmds "github.com/ipfs/go-ipfs-cmds"
@@ -118,6 +119,7 @@ START RUNNING FILECOIN
 STORE AND RETRIEVE DATA
   go-filecoin client                 - Make deals, store data, retrieve data
   go-filecoin retrieval-client       - Manage retrieval client operations
+  go-filecoin market                 - Query the storage market
 
 MINE
   go-filecoin miner                  - Manage a single miner actor
@@ -195,6 +197,7 @@ var rootSubcmdsDaemon = map[string]*cmds.Command{
 	"inspect":          inspectCmd,
 	"leb128":           leb128Cmd,
 	"log":              logCmd,
+	"market":           marketCmd,
 	"message":          msgCmd,
 	"miner":            minerCmd,
 	"mining":           miningCmd,
@@ -362,6 +365,18 @@ var priceOption = cmdkit.StringOption("gas-price", "Price (FIL e.g. 0.00013) to
 var limitOption = cmdkit.Int64Option("gas-limit", "Maximum GasUnits this message is allowed to consume")
 var previewOption = cmdkit.BoolOption("preview", "Preview the Gas cost of this command without actually executing it")
 
+var retryOption = cmdkit.UintOption("retry", "Number of times to resubmit the message if sending it fails").WithDefault(uint(0))
+var retryDelayOption = cmdkit.StringOption("retry-delay", "Delay before the first resubmission, doubled after each attempt. e.g. 500ms, 2s").WithDefault("1s")
+
+func parseRetryOptions(req *cmds.Request) (uint, time.Duration, error) {
+	retries, _ := req.Options["retry"].(uint)
+	delay, err := time.ParseDuration(req.Options["retry-delay"].(string))
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "invalid retry delay")
+	}
+	return retries, delay, nil
+}
+
 func parseGasOptions(req *cmds.Request) (types.AttoFIL, gas.Unit, bool, error) {
 	priceOption := req.Options["gas-price"]
 	if priceOption == nil {
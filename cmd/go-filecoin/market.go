@@ -0,0 +1,279 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/filecoin-project/go-fil-markets/storagemarket"
+	"github.com/filecoin-project/specs-actors/actors/abi"
+	cmdkit "github.com/ipfs/go-ipfs-cmdkit"
+	cmds "github.com/ipfs/go-ipfs-cmds"
+	"github.com/pkg/errors"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/types"
+)
+
+var marketCmd = &cmds.Command{
+	Helptext: cmdkit.HelpText{
+		Tagline: "Query the storage market",
+	},
+	Subcommands: map[string]*cmds.Command{
+		"asks":        marketAsksCmd,
+		"ask-at-most": marketAskAtMostCmd,
+	},
+}
+
+var marketAsksCmd = &cmds.Command{
+	Helptext: cmdkit.HelpText{
+		Tagline: "List live asks from all known miners, cheapest first",
+		ShortDescription: `
+Queries every miner with power on chain for its current ask and lists the asks
+that have not expired, ordered by price. Miners that cannot be reached or do not
+answer in time are skipped.
+`,
+	},
+	Options: []cmdkit.Option{
+		cmdkit.Uint64Option("max", "Maximum number of asks to list (0 for no limit)").WithDefault(uint64(0)),
+		cmdkit.StringOption("min-price", "Only list asks priced at least this much, in FIL"),
+		cmdkit.StringOption("max-price", "Only list asks priced at most this much, in FIL"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		minPrice, err := optionalPrice(req.Options["min-price"])
+		if err != nil {
+			return err
+		}
+		maxPrice, err := optionalPrice(req.Options["max-price"])
+		if err != nil {
+			return err
+		}
+
+		asks, err := liveAsks(req, env)
+		if err != nil {
+			return err
+		}
+
+		max, _ := req.Options["max"].(uint64)
+		return re.Emit(sortAsksByPrice(asksInPriceRange(asks, minPrice, maxPrice), max))
+	},
+	Type: []*storagemarket.SignedStorageAsk{},
+	Encoders: cmds.EncoderMap{
+		cmds.Text: cmds.MakeTypedEncoder(func(req *cmds.Request, w io.Writer, asks []*storagemarket.SignedStorageAsk) error {
+			for _, ask := range asks {
+				if err := writeAskText(w, ask); err != nil {
+					return err
+				}
+			}
+			return nil
+		}),
+	},
+}
+
+var marketAskAtMostCmd = &cmds.Command{
+	Helptext: cmdkit.HelpText{
+		Tagline: "Find the most expensive live ask that does not exceed a price",
+		ShortDescription: `
+Queries every miner with power on chain for its current ask and shows the
+highest-priced live ask whose price is at most the given price in FIL.
+`,
+	},
+	Arguments: []cmdkit.Argument{
+		cmdkit.StringArg("price", true, false, "Maximum price in FIL per GiB per epoch"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		maxPrice, ok := types.NewAttoFILFromFILString(req.Arguments[0])
+		if !ok {
+			return errors.Errorf("could not parse price %s", req.Arguments[0])
+		}
+
+		asks, err := liveAsks(req, env)
+		if err != nil {
+			return err
+		}
+
+		ask, found := askAtMostPrice(asks, maxPrice)
+		if !found {
+			return errors.Errorf("no live ask priced at most %s FIL", types.AttoFILToFILString(maxPrice))
+		}
+		return re.Emit(ask)
+	},
+	Type: storagemarket.SignedStorageAsk{},
+	Encoders: cmds.EncoderMap{
+		cmds.Text: cmds.MakeTypedEncoder(func(req *cmds.Request, w io.Writer, ask storagemarket.SignedStorageAsk) error {
+			return writeAskText(w, &ask)
+		}),
+	},
+}
+
+// askPricePrecision is the number of decimal places of FIL shown for ask prices.
+const askPricePrecision = 9
+
+// writeAskText writes an ask as a single line of text, with its price in FIL and the
+// height at which it expires.
+func writeAskText(w io.Writer, ask *storagemarket.SignedStorageAsk) error {
+	_, err := fmt.Fprintf(w, "%s\t%s per GiB per epoch\texpires at %s\n", ask.Ask.Miner,
+		types.FormatFIL(ask.Ask.Price, askPricePrecision), types.ChainEpochString(ask.Ask.Expiry))
+	return err
+}
+
+const (
+	// askQueryTimeout bounds how long liveAsks waits for a single miner's ask.
+	askQueryTimeout = 10 * time.Second
+	// askListTimeout bounds how long liveAsks waits for all miners' asks.
+	askListTimeout = 30 * time.Second
+	// askQueryWorkers is the number of miners liveAsks queries at once.
+	askQueryWorkers = 16
+)
+
+// liveAsks queries every miner with power on chain for its current ask and
+// returns the asks that have not expired at the chain head. Miners whose state
+// cannot be read, that do not answer within askQueryTimeout or that have not
+// answered within askListTimeout are skipped.
+func liveAsks(req *cmds.Request, env cmds.Environment) ([]*storagemarket.SignedStorageAsk, error) {
+	porcelainAPI := GetPorcelainAPI(env)
+	head, err := porcelainAPI.ChainHead()
+	if err != nil {
+		return nil, err
+	}
+	height, err := head.Height()
+	if err != nil {
+		return nil, err
+	}
+
+	miners, err := porcelainAPI.MinerList(req.Context, head.Key())
+	if err != nil {
+		return nil, err
+	}
+	view, err := porcelainAPI.MinerStateView(head.Key())
+	if err != nil {
+		return nil, err
+	}
+
+	var providers []*storagemarket.StorageProviderInfo
+	for _, minerAddr := range miners {
+		info, err := view.MinerInfo(req.Context, minerAddr)
+		if err != nil {
+			continue
+		}
+		providers = append(providers, &storagemarket.StorageProviderInfo{
+			Address:    minerAddr,
+			Owner:      info.Owner,
+			Worker:     info.Worker,
+			SectorSize: uint64(info.SectorSize),
+			PeerID:     info.PeerId,
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context, askListTimeout)
+	defer cancel()
+	asks := queryLiveAsks(ctx, GetStorageAPI(env), providers, height, askQueryTimeout)
+	return asks, req.Context.Err()
+}
+
+type askQuerier interface {
+	QueryAsk(ctx context.Context, info *storagemarket.StorageProviderInfo) (*storagemarket.SignedStorageAsk, error)
+}
+
+// queryLiveAsks queries providers for their asks, askQueryWorkers at a time, until all have
+// answered or ctx is done. Asks that have expired at height, or that are not for the provider
+// that returned them, are dropped. The order of the result is not defined.
+func queryLiveAsks(ctx context.Context, querier askQuerier, providers []*storagemarket.StorageProviderInfo, height abi.ChainEpoch, queryTimeout time.Duration) []*storagemarket.SignedStorageAsk {
+	jobs := make(chan *storagemarket.StorageProviderInfo)
+	go func() {
+		defer close(jobs)
+		for _, provider := range providers {
+			select {
+			case jobs <- provider:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var lk sync.Mutex
+	var asks []*storagemarket.SignedStorageAsk
+	var wg sync.WaitGroup
+	for i := 0; i < askQueryWorkers && i < len(providers); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for provider := range jobs {
+				queryCtx, cancel := context.WithTimeout(ctx, queryTimeout)
+				ask, err := querier.QueryAsk(queryCtx, provider)
+				cancel()
+				if err != nil {
+					// unreachable miners don't prevent listing the rest of the market
+					continue
+				}
+				if ask.Ask == nil || ask.Ask.Miner != provider.Address || ask.Ask.Expiry < height {
+					continue
+				}
+				lk.Lock()
+				asks = append(asks, ask)
+				lk.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return asks
+}
+
+// sortAsksByPrice orders asks from cheapest to most expensive and truncates
+// the result to max entries. A max of zero lists all asks.
+func sortAsksByPrice(asks []*storagemarket.SignedStorageAsk, max uint64) []*storagemarket.SignedStorageAsk {
+	sorted := make([]*storagemarket.SignedStorageAsk, len(asks))
+	copy(sorted, asks)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Ask.Price.LessThan(sorted[j].Ask.Price)
+	})
+	if max > 0 && uint64(len(sorted)) > max {
+		sorted = sorted[:max]
+	}
+	return sorted
+}
+
+// asksInPriceRange returns the asks priced within [minPrice, maxPrice].
+// A nil bound leaves that side of the range open.
+func asksInPriceRange(asks []*storagemarket.SignedStorageAsk, minPrice, maxPrice *types.AttoFIL) []*storagemarket.SignedStorageAsk {
+	inRange := []*storagemarket.SignedStorageAsk{}
+	for _, ask := range asks {
+		if minPrice != nil && ask.Ask.Price.LessThan(*minPrice) {
+			continue
+		}
+		if maxPrice != nil && ask.Ask.Price.GreaterThan(*maxPrice) {
+			continue
+		}
+		inRange = append(inRange, ask)
+	}
+	return inRange
+}
+
+// optionalPrice parses an optional FIL amount option, returning nil when it is not set.
+func optionalPrice(o interface{}) (*types.AttoFIL, error) {
+	s, ok := o.(string)
+	if !ok || s == "" {
+		return nil, nil
+	}
+	price, valid := types.NewAttoFILFromFILString(s)
+	if !valid {
+		return nil, errors.Errorf("could not parse price %s", s)
+	}
+	return &price, nil
+}
+
+// askAtMostPrice returns the highest-priced ask whose price does not exceed maxPrice.
+func askAtMostPrice(asks []*storagemarket.SignedStorageAsk, maxPrice types.AttoFIL) (*storagemarket.SignedStorageAsk, bool) {
+	var best *storagemarket.SignedStorageAsk
+	for _, ask := range asks {
+		if ask.Ask.Price.GreaterThan(maxPrice) {
+			continue
+		}
+		if best == nil || ask.Ask.Price.GreaterThan(best.Ask.Price) {
+			best = ask
+		}
+	}
+	return best, best != nil
+}
@@ -0,0 +1,223 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-fil-markets/storagemarket"
+	"github.com/filecoin-project/specs-actors/actors/abi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/go-filecoin/internal/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/types"
+	vmaddr "github.com/filecoin-project/go-filecoin/internal/pkg/vm/address"
+)
+
+func TestSortAsksByPrice(t *testing.T) {
+	tf.UnitTest(t)
+
+	minerA := vmaddr.RequireIDAddress(t, 1)
+	minerB := vmaddr.RequireIDAddress(t, 2)
+	ask := func(miner address.Address, price int64) *storagemarket.SignedStorageAsk {
+		return &storagemarket.SignedStorageAsk{Ask: &storagemarket.StorageAsk{
+			Miner: miner,
+			Price: abi.NewTokenAmount(price),
+		}}
+	}
+
+	// asks collected from miner A followed by miner B
+	asks := []*storagemarket.SignedStorageAsk{ask(minerA, 30), ask(minerA, 10), ask(minerB, 20), ask(minerB, 5)}
+
+	t.Run("merges into one sorted list", func(t *testing.T) {
+		sorted := sortAsksByPrice(asks, 0)
+		var prices []int64
+		for _, a := range sorted {
+			prices = append(prices, a.Ask.Price.Int64())
+		}
+		assert.Equal(t, []int64{5, 10, 20, 30}, prices)
+		assert.Equal(t, minerB, sorted[0].Ask.Miner)
+		assert.Equal(t, minerA, sorted[1].Ask.Miner)
+	})
+
+	t.Run("max caps the result", func(t *testing.T) {
+		sorted := sortAsksByPrice(asks, 2)
+		assert.Len(t, sorted, 2)
+		assert.Equal(t, int64(5), sorted[0].Ask.Price.Int64())
+		assert.Equal(t, int64(10), sorted[1].Ask.Price.Int64())
+	})
+
+	t.Run("does not modify input", func(t *testing.T) {
+		sortAsksByPrice(asks, 0)
+		assert.Equal(t, int64(30), asks[0].Ask.Price.Int64())
+	})
+}
+
+func TestAskAtMostPrice(t *testing.T) {
+	tf.UnitTest(t)
+
+	minerA := vmaddr.RequireIDAddress(t, 1)
+	minerB := vmaddr.RequireIDAddress(t, 2)
+	ask := func(miner address.Address, price int64) *storagemarket.SignedStorageAsk {
+		return &storagemarket.SignedStorageAsk{Ask: &storagemarket.StorageAsk{
+			Miner: miner,
+			Price: abi.NewTokenAmount(price),
+		}}
+	}
+	asks := []*storagemarket.SignedStorageAsk{ask(minerA, 30), ask(minerA, 10), ask(minerB, 20), ask(minerB, 5)}
+
+	t.Run("picks the highest price not above max", func(t *testing.T) {
+		found, ok := askAtMostPrice(asks, abi.NewTokenAmount(25))
+		require.True(t, ok)
+		assert.Equal(t, int64(20), found.Ask.Price.Int64())
+		assert.Equal(t, minerB, found.Ask.Miner)
+	})
+
+	t.Run("includes an exact price match", func(t *testing.T) {
+		found, ok := askAtMostPrice(asks, abi.NewTokenAmount(10))
+		require.True(t, ok)
+		assert.Equal(t, int64(10), found.Ask.Price.Int64())
+	})
+
+	t.Run("not found below the cheapest ask", func(t *testing.T) {
+		_, ok := askAtMostPrice(asks, abi.NewTokenAmount(4))
+		assert.False(t, ok)
+	})
+}
+
+func TestAsksInPriceRange(t *testing.T) {
+	tf.UnitTest(t)
+
+	minerA := vmaddr.RequireIDAddress(t, 1)
+	ask := func(price int64) *storagemarket.SignedStorageAsk {
+		return &storagemarket.SignedStorageAsk{Ask: &storagemarket.StorageAsk{
+			Miner: minerA,
+			Price: abi.NewTokenAmount(price),
+		}}
+	}
+	asks := []*storagemarket.SignedStorageAsk{ask(5), ask(10), ask(20), ask(30)}
+	price := func(p int64) *types.AttoFIL {
+		amt := abi.NewTokenAmount(p)
+		return &amt
+	}
+	prices := func(asks []*storagemarket.SignedStorageAsk) []int64 {
+		out := []int64{}
+		for _, a := range asks {
+			out = append(out, a.Ask.Price.Int64())
+		}
+		return out
+	}
+
+	assert.Equal(t, []int64{10, 20}, prices(asksInPriceRange(asks, price(10), price(20))), "bounds are inclusive")
+	assert.Equal(t, []int64{20, 30}, prices(asksInPriceRange(asks, price(11), nil)))
+	assert.Equal(t, []int64{5, 10}, prices(asksInPriceRange(asks, nil, price(19))))
+	assert.Equal(t, []int64{5, 10, 20, 30}, prices(asksInPriceRange(asks, nil, nil)))
+	assert.Empty(t, asksInPriceRange(asks, price(21), price(29)))
+}
+
+func TestWriteAskText(t *testing.T) {
+	tf.UnitTest(t)
+
+	price, ok := types.NewAttoFILFromFILString("0.0000000025")
+	require.True(t, ok)
+	ask := &storagemarket.SignedStorageAsk{Ask: &storagemarket.StorageAsk{
+		Miner:  vmaddr.RequireIDAddress(t, 1),
+		Price:  price,
+		Expiry: abi.ChainEpoch(400),
+	}}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeAskText(&buf, ask))
+	assert.Equal(t, ask.Ask.Miner.String()+"\t0.000000002 FIL per GiB per epoch\texpires at 400\n", buf.String())
+}
+
+type fakeAskQuerier map[address.Address]func(ctx context.Context) (*storagemarket.SignedStorageAsk, error)
+
+func (q fakeAskQuerier) QueryAsk(ctx context.Context, info *storagemarket.StorageProviderInfo) (*storagemarket.SignedStorageAsk, error) {
+	return q[info.Address](ctx)
+}
+
+func TestQueryLiveAsks(t *testing.T) {
+	tf.UnitTest(t)
+
+	ctx := context.Background()
+	minerA := vmaddr.RequireIDAddress(t, 1)
+	minerB := vmaddr.RequireIDAddress(t, 2)
+	minerC := vmaddr.RequireIDAddress(t, 3)
+	height := abi.ChainEpoch(100)
+
+	answer := func(miner address.Address, price int64, expiry abi.ChainEpoch) func(context.Context) (*storagemarket.SignedStorageAsk, error) {
+		return func(context.Context) (*storagemarket.SignedStorageAsk, error) {
+			return &storagemarket.SignedStorageAsk{Ask: &storagemarket.StorageAsk{
+				Miner:  miner,
+				Price:  abi.NewTokenAmount(price),
+				Expiry: expiry,
+			}}, nil
+		}
+	}
+	providers := func(miners ...address.Address) []*storagemarket.StorageProviderInfo {
+		var infos []*storagemarket.StorageProviderInfo
+		for _, m := range miners {
+			infos = append(infos, &storagemarket.StorageProviderInfo{Address: m})
+		}
+		return infos
+	}
+	prices := func(asks []*storagemarket.SignedStorageAsk) map[address.Address]int64 {
+		byMiner := make(map[address.Address]int64)
+		for _, a := range asks {
+			byMiner[a.Ask.Miner] = a.Ask.Price.Int64()
+		}
+		return byMiner
+	}
+
+	t.Run("merges asks from all miners", func(t *testing.T) {
+		querier := fakeAskQuerier{
+			minerA: answer(minerA, 30, height),
+			minerB: answer(minerB, 20, height+1),
+		}
+		asks := queryLiveAsks(ctx, querier, providers(minerA, minerB), height, time.Second)
+		require.Len(t, asks, 2)
+		assert.Equal(t, map[address.Address]int64{minerA: 30, minerB: 20}, prices(asks))
+	})
+
+	t.Run("skips expired asks, failing miners and asks for other miners", func(t *testing.T) {
+		querier := fakeAskQuerier{
+			minerA: answer(minerA, 30, height-1),
+			minerB: func(context.Context) (*storagemarket.SignedStorageAsk, error) {
+				return nil, fmt.Errorf("unreachable")
+			},
+			minerC: answer(minerA, 10, height),
+		}
+		asks := queryLiveAsks(ctx, querier, providers(minerA, minerB, minerC), height, time.Second)
+		assert.Empty(t, asks)
+	})
+
+	t.Run("a miner that does not answer does not hold up the others", func(t *testing.T) {
+		querier := fakeAskQuerier{
+			minerA: func(ctx context.Context) (*storagemarket.SignedStorageAsk, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+			minerB: answer(minerB, 20, height),
+		}
+		asks := queryLiveAsks(ctx, querier, providers(minerA, minerB), height, 10*time.Millisecond)
+		assert.Equal(t, map[address.Address]int64{minerB: 20}, prices(asks))
+	})
+
+	t.Run("stops at the overall deadline", func(t *testing.T) {
+		querier := fakeAskQuerier{
+			minerA: func(ctx context.Context) (*storagemarket.SignedStorageAsk, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+		}
+		listCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		asks := queryLiveAsks(listCtx, querier, providers(minerA), height, time.Hour)
+		assert.Empty(t, asks)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/filecoin-project/go-address"
@@ -58,6 +59,9 @@ var msgSendCmd = &cmds.Command{
 		priceOption,
 		limitOption,
 		previewOption,
+		retryOption,
+		retryDelayOption,
+		waitOption,
 		// TODO: (per dignifiedquire) add an option to set the nonce and method explicitly
 	},
 	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
@@ -108,27 +112,45 @@ var msgSendCmd = &cmds.Command{
 			})
 		}
 
-		c, _, err := GetPorcelainAPI(env).MessageSend(
-			req.Context,
-			fromAddr,
-			target,
-			val,
-			gasPrice,
-			gasLimit,
-			methodID,
-			adt.Empty,
-		)
+		retries, retryDelay, err := parseRetryOptions(req)
+		if err != nil {
+			return err
+		}
+
+		var c cid.Cid
+		err = retrySend(req.Context, retries, retryDelay, submitOrRepublish(req.Context, GetPorcelainAPI(env), fromAddr, &c, func() (cid.Cid, chan error, error) {
+			return GetPorcelainAPI(env).MessageSend(
+				req.Context,
+				fromAddr,
+				target,
+				val,
+				gasPrice,
+				gasLimit,
+				methodID,
+				adt.Empty,
+			)
+		}))
+		if err != nil {
+			return err
+		}
+
+		usedGas, err := waitForGasUsed(req, env, c)
 		if err != nil {
 			return err
 		}
 
 		return re.Emit(&MessageSendResult{
 			Cid:     c,
-			GasUsed: gas.NewGas(0),
+			GasUsed: usedGas,
 			Preview: false,
 		})
 	},
 	Type: &MessageSendResult{},
+	Encoders: cmds.EncoderMap{
+		cmds.Text: cmds.MakeTypedEncoder(func(req *cmds.Request, w io.Writer, res *MessageSendResult) error {
+			return writeSendResultText(req, w, res.Cid, res.GasUsed, res.Preview)
+		}),
+	},
 }
 
 var signedMsgSendCmd = &cmds.Command{
@@ -138,7 +160,10 @@ var signedMsgSendCmd = &cmds.Command{
 	Arguments: []cmdkit.Argument{
 		cmdkit.StringArg("message", true, false, "Signed Json message"),
 	},
-	Options: []cmdkit.Option{},
+	Options: []cmdkit.Option{
+		retryOption,
+		retryDelayOption,
+	},
 
 	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
 		msg := req.Arguments[0]
@@ -152,10 +177,18 @@ var signedMsgSendCmd = &cmds.Command{
 		}
 		signed := &m
 
-		c, _, err := GetPorcelainAPI(env).SignedMessageSend(
-			req.Context,
-			signed,
-		)
+		retries, retryDelay, err := parseRetryOptions(req)
+		if err != nil {
+			return err
+		}
+
+		var c cid.Cid
+		err = retrySend(req.Context, retries, retryDelay, submitOrRepublish(req.Context, GetPorcelainAPI(env), signed.Message.From, &c, func() (cid.Cid, chan error, error) {
+			return GetPorcelainAPI(env).SignedMessageSend(
+				req.Context,
+				signed,
+			)
+		}))
 		if err != nil {
 			return err
 		}
@@ -169,6 +202,112 @@ var signedMsgSendCmd = &cmds.Command{
 	Type: &MessageSendResult{},
 }
 
+// permanentSendError marks a send failure that resubmitting cannot fix.
+type permanentSendError struct {
+	err error
+}
+
+func (e *permanentSendError) Error() string {
+	return e.err.Error()
+}
+
+// sendOutcome waits for a queued message to be published and classifies the result of a
+// send attempt for retrySend. Messages the outbox rejects are never retried, while messages
+// that were queued but failed to publish (e.g. because the message pool is full) may be.
+func sendOutcome(ctx context.Context, sendErr error, pubErrCh chan error) error {
+	if sendErr != nil {
+		if _, rejected := errors.Cause(sendErr).(*message.RejectedError); rejected {
+			return &permanentSendError{sendErr}
+		}
+	} else {
+		sendErr = <-pubErrCh
+	}
+	if sendErr != nil && ctx.Err() != nil {
+		// the request was canceled, resubmitting cannot succeed
+		return &permanentSendError{sendErr}
+	}
+	return sendErr
+}
+
+var waitOption = cmdkit.BoolOption("wait", "Wait for the message to be mined and report the gas it used")
+
+// waitForGasUsed returns the gas used by a sent message once it is mined if the wait option
+// is set, or zero without waiting otherwise.
+// Waiting happens outside the retry loop: once a message is accepted it must not be
+// resubmitted, and an actor revert in its receipt is deterministic.
+func waitForGasUsed(req *cmds.Request, env cmds.Environment, c cid.Cid) (gas.Unit, error) {
+	if wait, _ := req.Options["wait"].(bool); !wait {
+		return gas.NewGas(0), nil
+	}
+	receipt, err := GetPorcelainAPI(env).MessageWaitDone(req.Context, c)
+	if err != nil {
+		return gas.NewGas(0), err
+	}
+	return receipt.GasUsed, nil
+}
+
+// writeSendResultText writes the cid of a sent message and, when the command previewed the
+// message or waited for it to be mined, the gas it used.
+func writeSendResultText(req *cmds.Request, w io.Writer, c cid.Cid, gasUsed gas.Unit, preview bool) error {
+	if preview {
+		_, err := fmt.Fprintf(w, "gas used: %d (preview)\n", gasUsed)
+		return err
+	}
+	if _, err := fmt.Fprintln(w, c); err != nil {
+		return err
+	}
+	if wait, _ := req.Options["wait"].(bool); wait {
+		_, err := fmt.Fprintf(w, "gas used: %d\n", gasUsed)
+		return err
+	}
+	return nil
+}
+
+type messageRepublisher interface {
+	MessageRepublish(ctx context.Context, from address.Address, c cid.Cid) (chan error, error)
+}
+
+// submitOrRepublish returns a send attempt for retrySend. The first attempt that gets the message
+// into the outbox queue records its cid in c; later attempts republish that queued message rather
+// than calling submit again, so a retry never creates a second message with another nonce.
+func submitOrRepublish(ctx context.Context, api messageRepublisher, from address.Address, c *cid.Cid, submit func() (cid.Cid, chan error, error)) func() error {
+	return func() error {
+		var pubErrCh chan error
+		var err error
+		if c.Defined() {
+			pubErrCh, err = api.MessageRepublish(ctx, from, *c)
+		} else {
+			*c, pubErrCh, err = submit()
+		}
+		return sendOutcome(ctx, err, pubErrCh)
+	}
+}
+
+// retrySend calls send until it succeeds, fails permanently, or has been retried retries
+// times, waiting delay before the first retry and doubling it after each attempt.
+// Send failures are treated as transient unless wrapped in a permanentSendError.
+func retrySend(ctx context.Context, retries uint, delay time.Duration, send func() error) error {
+	for attempt := uint(0); ; attempt++ {
+		err := send()
+		if err == nil {
+			return nil
+		}
+		if perm, ok := err.(*permanentSendError); ok {
+			return perm.err
+		}
+		if attempt >= retries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
 // WaitResult is the result of a message wait call.
 type WaitResult struct {
 	Message   *types.SignedMessage
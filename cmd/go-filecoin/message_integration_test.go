@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/specs-actors/actors/builtin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/filecoin-project/go-filecoin/fixtures/fortest"
 	"github.com/filecoin-project/go-filecoin/internal/app/go-filecoin/node"
 	"github.com/filecoin-project/go-filecoin/internal/app/go-filecoin/node/test"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/clock"
 	tf "github.com/filecoin-project/go-filecoin/internal/pkg/testhelpers/testflags"
 	"github.com/filecoin-project/go-filecoin/internal/pkg/types"
 )
@@ -130,6 +133,25 @@ func TestMessageWait(t *testing.T) {
 		assert.Equal(t, fortest.TestAddresses[1], waitResult.Message.Message.To)
 	})
 
+	t.Run("[success] send and wait reports gas used", func(t *testing.T) {
+		sendCtx, cancel := context.WithTimeout(ctx, time.Minute)
+		defer cancel()
+		mineErr := mineInBackground(sendCtx, fakeClock, node)
+
+		var sendResult commands.MessageSendResult
+		cmdClient.RunMarshaledJSON(sendCtx, &sendResult, "message", "send",
+			"--gas-price", "1",
+			"--gas-limit", "300",
+			"--wait",
+			fortest.TestAddresses[1].String(),
+		)
+		cancel()
+		require.NoError(t, <-mineErr)
+
+		assert.False(t, sendResult.Preview)
+		assert.True(t, sendResult.GasUsed > 0)
+	})
+
 	t.Run("[success] lookback", func(t *testing.T) {
 		var sendResult commands.MessageSendResult
 		cmdClient.RunMarshaledJSON(ctx, &sendResult, "message", "send",
@@ -263,3 +285,26 @@ func TestMessageStatus(t *testing.T) {
 		assert.NotContains(t, status, "On chain")
 	})
 }
+
+// mineInBackground mines a block every 100ms until ctx is done, so that a command waiting for
+// its message to be mined can return. The returned channel receives the first mining error,
+// or nil once ctx is done; require may only be called from the test goroutine.
+func mineInBackground(ctx context.Context, fakeClock clock.Fake, nd *node.Node) <-chan error {
+	mineErr := make(chan error, 1)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				mineErr <- nil
+				return
+			case <-time.After(100 * time.Millisecond):
+				fakeClock.Advance(builtin.EpochDurationSeconds * time.Second)
+				if _, err := nd.BlockMining.BlockMiningAPI.MiningOnce(ctx); err != nil && ctx.Err() == nil {
+					mineErr <- err
+					return
+				}
+			}
+		}
+	}()
+	return mineErr
+}
@@ -0,0 +1,147 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/specs-actors/actors/abi"
+	"github.com/filecoin-project/specs-actors/actors/builtin"
+	"github.com/filecoin-project/specs-actors/actors/util/adt"
+	"github.com/ipfs/go-cid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/block"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/chain"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/clock"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/journal"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/message"
+	tf "github.com/filecoin-project/go-filecoin/internal/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/types"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/vm/actor"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/vm/gas"
+)
+
+// outboxRepublisher republishes through an outbox the way the plumbing API does.
+type outboxRepublisher struct {
+	ob *message.Outbox
+}
+
+func (r outboxRepublisher) MessageRepublish(ctx context.Context, from address.Address, c cid.Cid) (chan error, error) {
+	return r.ob.Republish(ctx, from, c, true)
+}
+
+func TestRetrySend(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	errPoolFull := errors.New("message pool is full")
+
+	w, _ := types.NewMockSignersAndKeyInfo(1)
+	sender := w.Addresses[0]
+	provider := message.NewFakeProvider(t)
+	head := provider.BuildOneOn(block.UndefTipSet, func(b *chain.BlockBuilder) {
+		b.IncHeight(1000)
+	})
+	provider.SetHeadAndActor(t, head.Key(), sender, actor.NewActor(builtin.AccountActorCodeID, abi.NewTokenAmount(0), cid.Undef))
+
+	newOutbox := func(validator message.FakeValidator, publisher *message.MockPublisher) (*message.Outbox, *message.Queue) {
+		jw := journal.NewInMemoryJournal(t, clock.NewFake(time.Unix(1234567890, 0))).Topic("outbox")
+		queue := message.NewQueue()
+		return message.NewOutbox(w, validator, queue, publisher, message.NullPolicy{}, provider, provider, jw), queue
+	}
+
+	// newSend returns a send attempt through a real outbox, along with a count of the attempts made.
+	newSend := func(ob *message.Outbox, c *cid.Cid) (func() error, *int) {
+		calls := 0
+		send := submitOrRepublish(ctx, outboxRepublisher{ob}, sender, c, func() (cid.Cid, chan error, error) {
+			return ob.Send(ctx, sender, sender, types.ZeroAttoFIL, types.NewGasPrice(0), gas.NewGas(0), true, builtin.MethodSend, adt.Empty)
+		})
+		return func() error {
+			calls++
+			return send()
+		}, &calls
+	}
+
+	// assertQueuedOnce checks that retries left exactly one message, with the first nonce, in the queue.
+	assertQueuedOnce := func(t *testing.T, queue *message.Queue, c cid.Cid) {
+		queued := queue.List(sender)
+		require.Len(t, queued, 1)
+		assert.Equal(t, uint64(0), queued[0].Msg.Message.CallSeqNum)
+		qc, err := queued[0].Msg.Cid()
+		require.NoError(t, err)
+		assert.Equal(t, c, qc)
+	}
+
+	t.Run("publish failures are retried with the queued message", func(t *testing.T) {
+		publisher := &message.MockPublisher{ReturnError: errPoolFull}
+		ob, queue := newOutbox(message.FakeValidator{}, publisher)
+		var c cid.Cid
+		send, calls := newSend(ob, &c)
+		err := retrySend(ctx, 3, time.Millisecond, func() error {
+			if *calls == 2 {
+				publisher.ReturnError = nil
+			}
+			return send()
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, *calls)
+		assertQueuedOnce(t, queue, c)
+		assert.Equal(t, uint64(0), publisher.Message.Message.CallSeqNum)
+	})
+
+	t.Run("retries are bounded", func(t *testing.T) {
+		ob, queue := newOutbox(message.FakeValidator{}, &message.MockPublisher{ReturnError: errPoolFull})
+		var c cid.Cid
+		send, calls := newSend(ob, &c)
+		err := retrySend(ctx, 2, time.Millisecond, send)
+		assert.Equal(t, errPoolFull, err)
+		assert.Equal(t, 3, *calls)
+		assertQueuedOnce(t, queue, c)
+	})
+
+	t.Run("signed messages are retried without being enqueued again", func(t *testing.T) {
+		publisher := &message.MockPublisher{ReturnError: errPoolFull}
+		ob, queue := newOutbox(message.FakeValidator{}, publisher)
+		signed, err := types.NewSignedMessage(ctx, *types.NewMeteredMessage(sender, sender, 0, types.ZeroAttoFIL, builtin.MethodSend, []byte{}, types.NewGasPrice(0), gas.NewGas(0)), w)
+		require.NoError(t, err)
+
+		var c cid.Cid
+		calls := 0
+		send := submitOrRepublish(ctx, outboxRepublisher{ob}, sender, &c, func() (cid.Cid, chan error, error) {
+			return ob.SignedSend(ctx, signed, true)
+		})
+		err = retrySend(ctx, 3, time.Millisecond, func() error {
+			calls++
+			if calls == 2 {
+				publisher.ReturnError = nil
+			}
+			return send()
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, calls)
+		assertQueuedOnce(t, queue, c)
+	})
+
+	t.Run("rejected messages are not retried", func(t *testing.T) {
+		ob, queue := newOutbox(message.FakeValidator{RejectMessages: true}, &message.MockPublisher{})
+		var c cid.Cid
+		send, calls := newSend(ob, &c)
+		err := retrySend(ctx, 3, time.Millisecond, send)
+		require.Error(t, err)
+		assert.IsType(t, &message.RejectedError{}, err)
+		assert.Equal(t, 1, *calls)
+		assert.Empty(t, queue.List(sender))
+	})
+
+	t.Run("no retries by default", func(t *testing.T) {
+		ob, _ := newOutbox(message.FakeValidator{}, &message.MockPublisher{ReturnError: errPoolFull})
+		var c cid.Cid
+		send, calls := newSend(ob, &c)
+		err := retrySend(ctx, 0, time.Millisecond, send)
+		assert.Equal(t, errPoolFull, err)
+		assert.Equal(t, 1, *calls)
+	})
+}
@@ -2,7 +2,10 @@ package commands
 
 import (
 	"fmt"
+	"io"
 	"math/big"
+	"strconv"
+	"time"
 
 	address "github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/sector-storage/ffiwrapper"
@@ -26,11 +29,18 @@ var minerCmd = &cmds.Command{
 		Tagline: "Manage a single miner actor",
 	},
 	Subcommands: map[string]*cmds.Command{
-		"create":        minerCreateCmd,
-		"status":        minerStatusCommand,
-		"set-price":     minerSetPriceCmd,
-		"update-peerid": minerUpdatePeerIDCmd,
-		"set-worker":    minerSetWorkerAddressCmd,
+		"create":         minerCreateCmd,
+		"dump":           minerDumpCommand,
+		"status":         minerStatusCommand,
+		"faults":         minerFaultsCommand,
+		"list":           minerListCommand,
+		"proven-sectors": minerProvenSectorsCommand,
+		"proving-status": minerProvingStatusCommand,
+		"sector":         minerSectorCommand,
+		"set-price":      minerSetPriceCmd,
+		"update-peerid":  minerUpdatePeerIDCmd,
+		"set-worker":     minerSetWorkerAddressCmd,
+		"watch":          minerWatchCommand,
 	},
 }
 
@@ -203,6 +213,9 @@ var minerUpdatePeerIDCmd = &cmds.Command{
 		priceOption,
 		limitOption,
 		previewOption,
+		retryOption,
+		retryDelayOption,
+		waitOption,
 	},
 	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
 		minerAddr, err := address.NewFromString(req.Arguments[0])
@@ -244,29 +257,47 @@ var minerUpdatePeerIDCmd = &cmds.Command{
 			})
 		}
 
+		retries, retryDelay, err := parseRetryOptions(req)
+		if err != nil {
+			return err
+		}
+
 		params := miner.ChangePeerIDParams{NewID: newPid}
 
-		c, _, err := GetPorcelainAPI(env).MessageSend(
-			req.Context,
-			fromAddr,
-			minerAddr,
-			types.ZeroAttoFIL,
-			gasPrice,
-			gasLimit,
-			builtin.MethodsMiner.ChangePeerID,
-			&params,
-		)
+		var c cid.Cid
+		err = retrySend(req.Context, retries, retryDelay, submitOrRepublish(req.Context, GetPorcelainAPI(env), fromAddr, &c, func() (cid.Cid, chan error, error) {
+			return GetPorcelainAPI(env).MessageSend(
+				req.Context,
+				fromAddr,
+				minerAddr,
+				types.ZeroAttoFIL,
+				gasPrice,
+				gasLimit,
+				builtin.MethodsMiner.ChangePeerID,
+				&params,
+			)
+		}))
+		if err != nil {
+			return err
+		}
+
+		usedGas, err := waitForGasUsed(req, env, c)
 		if err != nil {
 			return err
 		}
 
 		return re.Emit(&MinerUpdatePeerIDResult{
 			Cid:     c,
-			GasUsed: gas.NewGas(0),
+			GasUsed: usedGas,
 			Preview: false,
 		})
 	},
 	Type: &MinerUpdatePeerIDResult{},
+	Encoders: cmds.EncoderMap{
+		cmds.Text: cmds.MakeTypedEncoder(func(req *cmds.Request, w io.Writer, res *MinerUpdatePeerIDResult) error {
+			return writeSendResultText(req, w, res.Cid, res.GasUsed, res.Preview)
+		}),
+	},
 }
 
 var minerStatusCommand = &cmds.Command{
@@ -280,15 +311,247 @@ var minerStatusCommand = &cmds.Command{
 		}
 
 		porcelainAPI := GetPorcelainAPI(env)
-		status, err := porcelainAPI.MinerGetStatus(req.Context, minerAddr, porcelainAPI.ChainHeadKey())
+		var status porcelain.MinerStatus
+		if h, ok := req.Options["at-height"].(string); ok {
+			height, err := types.ParseChainEpoch(h)
+			if err != nil {
+				return err
+			}
+			status, err = porcelainAPI.MinerGetStatusAtHeight(req.Context, minerAddr, height)
+			if err != nil {
+				return err
+			}
+		} else {
+			status, err = porcelainAPI.MinerGetStatus(req.Context, minerAddr, porcelainAPI.ChainHeadKey())
+			if err != nil {
+				return err
+			}
+		}
+		return re.Emit(status)
+	},
+	Type: porcelain.MinerStatus{},
+	Arguments: []cmdkit.Argument{
+		cmdkit.StringArg("miner", true, false, "A miner actor address"),
+	},
+	Options: []cmdkit.Option{
+		cmdkit.StringOption("at-height", "Report the miner's status as of the tipset at this chain height"),
+	},
+}
+
+var minerProvenSectorsCommand = &cmds.Command{
+	Helptext: cmdkit.HelpText{
+		Tagline: "List a miner's committed sectors that are not faulted",
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		minerAddr, err := optionalAddr(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+
+		porcelainAPI := GetPorcelainAPI(env)
+		sectors, err := porcelainAPI.MinerGetProvenSectors(req.Context, minerAddr, porcelainAPI.ChainHeadKey())
+		if err != nil {
+			return err
+		}
+		return re.Emit(sectors)
+	},
+	Type: []abi.SectorNumber{},
+	Arguments: []cmdkit.Argument{
+		cmdkit.StringArg("miner", true, false, "A miner actor address"),
+	},
+}
+
+var minerProvingStatusCommand = &cmds.Command{
+	Helptext: cmdkit.HelpText{
+		Tagline: "Show a miner's progress through its current proving period",
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		minerAddr, err := optionalAddr(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+
+		porcelainAPI := GetPorcelainAPI(env)
+		status, err := porcelainAPI.MinerGetProvingStatus(req.Context, minerAddr, porcelainAPI.ChainHeadKey())
 		if err != nil {
 			return err
 		}
 		return re.Emit(status)
 	},
-	Type: porcelain.MinerStatus{},
+	Type: porcelain.MinerProvingStatus{},
+	Arguments: []cmdkit.Argument{
+		cmdkit.StringArg("miner", true, false, "A miner actor address"),
+	},
+}
+
+var minerWatchCommand = &cmds.Command{
+	Helptext: cmdkit.HelpText{
+		Tagline: "Stream events as a miner's proving period advances or sectors are committed",
+		ShortDescription: `
+Watches the chain head and emits an event each time the miner's proving period
+start advances or new sectors are committed. Runs until interrupted.
+`,
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		minerAddr, err := optionalAddr(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+
+		porcelainAPI := GetPorcelainAPI(env)
+		headKey := porcelainAPI.ChainHeadKey()
+		prev, err := porcelainAPI.MinerGetStatus(req.Context, minerAddr, headKey)
+		if err != nil {
+			return err
+		}
+
+		ticker := time.NewTicker(porcelainAPI.BlockTime())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-req.Context.Done():
+				return nil
+			case <-ticker.C:
+			}
+
+			head, err := porcelainAPI.ChainHead()
+			if err != nil {
+				return err
+			}
+			if head.Key().Equals(headKey) {
+				continue
+			}
+			headKey = head.Key()
+
+			cur, err := porcelainAPI.MinerGetStatus(req.Context, minerAddr, headKey)
+			if err != nil {
+				return err
+			}
+			height, err := head.Height()
+			if err != nil {
+				return err
+			}
+			if event, changed := porcelain.MinerWatchChange(height, prev, cur); changed {
+				if err := re.Emit(event); err != nil {
+					return err
+				}
+			}
+			prev = cur
+		}
+	},
+	Type: porcelain.MinerWatchEvent{},
+	Arguments: []cmdkit.Argument{
+		cmdkit.StringArg("miner", true, false, "A miner actor address"),
+	},
+}
+
+var minerDumpCommand = &cmds.Command{
+	Helptext: cmdkit.HelpText{
+		Tagline: "Export a miner's on-chain state",
+		ShortDescription: `
+Writes a snapshot of the miner's on-chain state at the chain head: its status,
+proving period progress, faulted sectors and committed sectors. Use --enc=json
+to keep the output as a backup.
+`,
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		minerAddr, err := optionalAddr(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+
+		porcelainAPI := GetPorcelainAPI(env)
+		dump, err := porcelainAPI.MinerGetDump(req.Context, minerAddr, porcelainAPI.ChainHeadKey())
+		if err != nil {
+			return err
+		}
+		return re.Emit(dump)
+	},
+	Type: porcelain.MinerDump{},
+	Arguments: []cmdkit.Argument{
+		cmdkit.StringArg("miner", true, false, "A miner actor address"),
+	},
+}
+
+var minerListCommand = &cmds.Command{
+	Helptext: cmdkit.HelpText{
+		Tagline:          "List the miners owned by an address",
+		ShortDescription: "Lists the addresses of all miners whose owner is --owner, which defaults to the wallet's default address.",
+	},
+	Options: []cmdkit.Option{
+		cmdkit.StringOption("owner", "Owner address to list miners for"),
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		owner, err := optionalAddr(req.Options["owner"])
+		if err != nil {
+			return err
+		}
+		if owner.Empty() {
+			owner, err = GetPorcelainAPI(env).WalletDefaultAddress()
+			if err != nil {
+				return err
+			}
+		}
+
+		porcelainAPI := GetPorcelainAPI(env)
+		miners, err := porcelainAPI.MinerListByOwner(req.Context, owner, porcelainAPI.ChainHeadKey())
+		if err != nil {
+			return err
+		}
+		return re.Emit(miners)
+	},
+	Type: []address.Address{},
+}
+
+var minerFaultsCommand = &cmds.Command{
+	Helptext: cmdkit.HelpText{
+		Tagline: "List the sector numbers a miner currently has faulted",
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		minerAddr, err := optionalAddr(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+
+		porcelainAPI := GetPorcelainAPI(env)
+		faults, err := porcelainAPI.MinerGetFaults(req.Context, minerAddr, porcelainAPI.ChainHeadKey())
+		if err != nil {
+			return err
+		}
+		return re.Emit(faults)
+	},
+	Type: []abi.SectorNumber{},
+	Arguments: []cmdkit.Argument{
+		cmdkit.StringArg("miner", true, false, "A miner actor address"),
+	},
+}
+
+var minerSectorCommand = &cmds.Command{
+	Helptext: cmdkit.HelpText{
+		Tagline: "Show the on-chain commitment of a single miner sector",
+	},
+	Run: func(req *cmds.Request, re cmds.ResponseEmitter, env cmds.Environment) error {
+		minerAddr, err := optionalAddr(req.Arguments[0])
+		if err != nil {
+			return err
+		}
+
+		sectorNum, err := strconv.ParseUint(req.Arguments[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid sector number: %s", req.Arguments[1])
+		}
+
+		porcelainAPI := GetPorcelainAPI(env)
+		info, err := porcelainAPI.MinerGetSector(req.Context, minerAddr, abi.SectorNumber(sectorNum), porcelainAPI.ChainHeadKey())
+		if err != nil {
+			return err
+		}
+		return re.Emit(info)
+	},
+	Type: miner.SectorOnChainInfo{},
 	Arguments: []cmdkit.Argument{
 		cmdkit.StringArg("miner", true, false, "A miner actor address"),
+		cmdkit.StringArg("sector", true, false, "The sector number to inspect"),
 	},
 }
 
@@ -2,15 +2,18 @@ package commands_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/filecoin-project/specs-actors/actors/abi"
+	"github.com/ipfs/go-cid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	commands "github.com/filecoin-project/go-filecoin/cmd/go-filecoin"
 	"github.com/filecoin-project/go-filecoin/internal/app/go-filecoin/node/test"
+	th "github.com/filecoin-project/go-filecoin/internal/pkg/testhelpers"
 	tf "github.com/filecoin-project/go-filecoin/internal/pkg/testhelpers/testflags"
 	"github.com/filecoin-project/go-filecoin/internal/pkg/types"
 )
@@ -71,3 +74,57 @@ func TestSetPrice(t *testing.T) {
 	assert.Equal(t, abi.NewTokenAmount(1000), asks[0].Ask.Price)
 	assert.Equal(t, abi.ChainEpoch(400), asks[0].Ask.Expiry)
 }
+
+func TestMinerUpdatePeerIDWait(t *testing.T) {
+	tf.IntegrationTest(t)
+	ctx := context.Background()
+
+	seed, genCfg, fakeClock, chainClock := test.CreateBootstrapSetup(t)
+	node := test.CreateBootstrapMiner(ctx, t, seed, chainClock, genCfg)
+
+	cmdClient, clientStop := test.RunNodeAPI(ctx, node, t)
+	defer clientStop()
+
+	minerAddr := node.Repo.Config().Mining.MinerAddress
+
+	// sendAndWait changes the miner's peer ID with --wait, mining until the command returns.
+	sendAndWait := func(t *testing.T, run func(ctx context.Context, args ...string)) {
+		sendCtx, cancel := context.WithTimeout(ctx, time.Minute)
+		defer cancel()
+		mineErr := mineInBackground(sendCtx, fakeClock, node)
+
+		run(sendCtx, "miner", "update-peerid",
+			"--gas-price", "1",
+			"--gas-limit", "10000",
+			"--wait",
+			minerAddr.String(),
+			th.RequireRandomPeerID(t).String(),
+		)
+		cancel()
+		require.NoError(t, <-mineErr)
+	}
+
+	t.Run("json", func(t *testing.T) {
+		var result commands.MinerUpdatePeerIDResult
+		sendAndWait(t, func(ctx context.Context, args ...string) {
+			cmdClient.RunMarshaledJSON(ctx, &result, append([]string{"--enc=json"}, args...)...)
+		})
+		assert.True(t, result.Cid.Defined())
+		assert.True(t, result.GasUsed > 0)
+	})
+
+	t.Run("text", func(t *testing.T) {
+		var lines []string
+		sendAndWait(t, func(ctx context.Context, args ...string) {
+			lines = cmdClient.RunSuccessLines(ctx, append([]string{"--enc=text"}, args...)...)
+		})
+		require.Len(t, lines, 2)
+		_, err := cid.Decode(lines[0])
+		require.NoError(t, err)
+
+		var gasUsed int64
+		_, err = fmt.Sscanf(lines[1], "gas used: %d", &gasUsed)
+		require.NoError(t, err)
+		assert.True(t, gasUsed > 0)
+	})
+}
@@ -248,6 +248,12 @@ func (api *API) SignedMessageSend(ctx context.Context, smsg *types.SignedMessage
 	return api.outbox.SignedSend(ctx, smsg, true)
 }
 
+// MessageRepublish publishes a message from the outbox queue again without creating a new
+// message. The error channel behaves as for MessageSend.
+func (api *API) MessageRepublish(ctx context.Context, from address.Address, c cid.Cid) (chan error, error) {
+	return api.outbox.Republish(ctx, from, c, true)
+}
+
 // MessageWait invokes the callback when a message with the given cid appears on chain.
 // It will find the message in both the case that it is already on chain and
 // the case that it appears in a newly mined block. An error is returned if one is
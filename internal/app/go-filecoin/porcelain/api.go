@@ -7,6 +7,7 @@ import (
 
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/specs-actors/actors/abi"
+	"github.com/filecoin-project/specs-actors/actors/builtin/miner"
 	"github.com/ipfs/go-cid"
 	"github.com/libp2p/go-libp2p-core/peer"
 
@@ -51,6 +52,11 @@ func (a *API) ChainHead() (block.TipSet, error) {
 	return ChainHead(a)
 }
 
+// ChainTipSetAtHeight returns the highest tipset at or below height on the current chain
+func (a *API) ChainTipSetAtHeight(ctx context.Context, height abi.ChainEpoch) (block.TipSet, error) {
+	return ChainTipSetAtHeight(ctx, a, height)
+}
+
 // ChainGetFullBlock returns the full block given the header cid
 func (a *API) ChainGetFullBlock(ctx context.Context, id cid.Cid) (*block.FullBlock, error) {
 	return GetFullBlock(ctx, a, id)
@@ -90,6 +96,46 @@ func (a *API) MinerGetStatus(ctx context.Context, minerAddr address.Address, bas
 	return MinerGetStatus(ctx, a, minerAddr, baseKey)
 }
 
+// MinerGetStatusAtHeight queries for status of a miner as of the tipset at a chain height.
+func (a *API) MinerGetStatusAtHeight(ctx context.Context, minerAddr address.Address, height abi.ChainEpoch) (MinerStatus, error) {
+	return MinerGetStatusAtHeight(ctx, a, minerAddr, height)
+}
+
+// MinerGetProvingStatus queries for a miner's progress through its proving period.
+func (a *API) MinerGetProvingStatus(ctx context.Context, minerAddr address.Address, baseKey block.TipSetKey) (MinerProvingStatus, error) {
+	return MinerGetProvingStatus(ctx, a, minerAddr, baseKey)
+}
+
+// MinerList queries for the addresses of all miners.
+func (a *API) MinerList(ctx context.Context, baseKey block.TipSetKey) ([]address.Address, error) {
+	return MinerList(ctx, a, baseKey)
+}
+
+// MinerListByOwner queries for the miners owned by an address.
+func (a *API) MinerListByOwner(ctx context.Context, owner address.Address, baseKey block.TipSetKey) ([]address.Address, error) {
+	return MinerListByOwner(ctx, a, owner, baseKey)
+}
+
+// MinerGetDump queries for a snapshot of a miner's on-chain state.
+func (a *API) MinerGetDump(ctx context.Context, minerAddr address.Address, baseKey block.TipSetKey) (MinerDump, error) {
+	return MinerGetDump(ctx, a, minerAddr, baseKey)
+}
+
+// MinerGetProvenSectors queries for a miner's committed sectors that are not faulted.
+func (a *API) MinerGetProvenSectors(ctx context.Context, minerAddr address.Address, baseKey block.TipSetKey) ([]abi.SectorNumber, error) {
+	return MinerGetProvenSectors(ctx, a, minerAddr, baseKey)
+}
+
+// MinerGetFaults queries for the faulted sectors of a miner.
+func (a *API) MinerGetFaults(ctx context.Context, minerAddr address.Address, baseKey block.TipSetKey) ([]abi.SectorNumber, error) {
+	return MinerGetFaults(ctx, a, minerAddr, baseKey)
+}
+
+// MinerGetSector queries for the on-chain info of a single miner sector.
+func (a *API) MinerGetSector(ctx context.Context, minerAddr address.Address, sectorNum abi.SectorNumber, baseKey block.TipSetKey) (*miner.SectorOnChainInfo, error) {
+	return MinerGetSector(ctx, a, minerAddr, sectorNum, baseKey)
+}
+
 // ProtocolParameters fetches the current protocol configuration parameters.
 func (a *API) ProtocolParameters(ctx context.Context) (*ProtocolParams, error) {
 	return ProtocolParameters(ctx, a)
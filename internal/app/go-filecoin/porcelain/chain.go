@@ -3,9 +3,12 @@ package porcelain
 import (
 	"context"
 
+	"github.com/filecoin-project/specs-actors/actors/abi"
 	"github.com/ipfs/go-cid"
+	"github.com/pkg/errors"
 
 	"github.com/filecoin-project/go-filecoin/internal/pkg/block"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/chain"
 	"github.com/filecoin-project/go-filecoin/internal/pkg/types"
 )
 
@@ -19,6 +22,32 @@ func ChainHead(plumbing chainHeadPlumbing) (block.TipSet, error) {
 	return plumbing.ChainTipSet(plumbing.ChainHeadKey())
 }
 
+// ChainTipSetAtHeight returns the highest tipset at or below height on the chain ending in the current head.
+// It is an error to ask for a height above the head.
+func ChainTipSetAtHeight(ctx context.Context, plumbing chainHeadPlumbing, height abi.ChainEpoch) (block.TipSet, error) {
+	head, err := ChainHead(plumbing)
+	if err != nil {
+		return block.UndefTipSet, err
+	}
+	headHeight, err := head.Height()
+	if err != nil {
+		return block.UndefTipSet, err
+	}
+	if height > headHeight {
+		return block.UndefTipSet, errors.Errorf("height %d is above the chain head at height %d", height, headHeight)
+	}
+	return chain.FindTipsetAtEpoch(ctx, head, height, tipSetProvider{plumbing})
+}
+
+// tipSetProvider adapts chain head plumbing to a chain.TipSetProvider.
+type tipSetProvider struct {
+	plumbing chainHeadPlumbing
+}
+
+func (p tipSetProvider) GetTipSet(key block.TipSetKey) (block.TipSet, error) {
+	return p.plumbing.ChainTipSet(key)
+}
+
 type fullBlockPlumbing interface {
 	ChainGetBlock(context.Context, cid.Cid) (*block.Block, error)
 	ChainGetMessages(context.Context, cid.Cid) ([]*types.UnsignedMessage, []*types.SignedMessage, error)
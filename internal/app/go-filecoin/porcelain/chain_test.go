@@ -0,0 +1,60 @@
+package porcelain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/specs-actors/actors/abi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/filecoin-project/go-filecoin/internal/app/go-filecoin/porcelain"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/block"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/chain"
+	tf "github.com/filecoin-project/go-filecoin/internal/pkg/testhelpers/testflags"
+)
+
+type chainHeightPlumbing struct {
+	builder *chain.Builder
+	head    block.TipSetKey
+}
+
+func (p *chainHeightPlumbing) ChainHeadKey() block.TipSetKey {
+	return p.head
+}
+
+func (p *chainHeightPlumbing) ChainTipSet(key block.TipSetKey) (block.TipSet, error) {
+	return p.builder.GetTipSet(key)
+}
+
+func TestChainTipSetAtHeight(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+
+	builder := chain.NewBuilder(t, address.Undef)
+	genesis := builder.NewGenesis()
+	ts1 := builder.AppendOn(genesis, 1)
+	// leave a null round at height 2
+	ts3 := builder.BuildOneOn(ts1, func(b *chain.BlockBuilder) { b.IncHeight(1) })
+	head := builder.AppendOn(ts3, 1)
+
+	plumbing := &chainHeightPlumbing{builder: builder, head: head.Key()}
+
+	ts, err := ChainTipSetAtHeight(ctx, plumbing, abi.ChainEpoch(1))
+	require.NoError(t, err)
+	assert.Equal(t, ts1.Key(), ts.Key())
+
+	ts, err = ChainTipSetAtHeight(ctx, plumbing, abi.ChainEpoch(2))
+	require.NoError(t, err)
+	assert.Equal(t, ts1.Key(), ts.Key())
+
+	headHeight, err := head.Height()
+	require.NoError(t, err)
+	ts, err = ChainTipSetAtHeight(ctx, plumbing, headHeight)
+	require.NoError(t, err)
+	assert.Equal(t, head.Key(), ts.Key())
+
+	_, err = ChainTipSetAtHeight(ctx, plumbing, abi.ChainEpoch(100))
+	assert.Error(t, err)
+}
@@ -3,6 +3,7 @@ package porcelain
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/filecoin-project/go-filecoin/internal/app/go-filecoin/plumbing/msg"
 
@@ -10,7 +11,9 @@ import (
 
 	address "github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/specs-actors/actors/abi"
+	"github.com/filecoin-project/specs-actors/actors/abi/big"
 	"github.com/filecoin-project/specs-actors/actors/builtin"
+	initact "github.com/filecoin-project/specs-actors/actors/builtin/init"
 	"github.com/filecoin-project/specs-actors/actors/builtin/power"
 	"github.com/filecoin-project/specs-actors/actors/runtime/exitcode"
 	cid "github.com/ipfs/go-cid"
@@ -43,6 +46,13 @@ type MinerStateView interface {
 	PowerNetworkTotal(ctx context.Context) (*state.NetworkPower, error)
 	MinerClaimedPower(ctx context.Context, miner address.Address) (raw, qa abi.StoragePower, err error)
 	MinerInfo(ctx context.Context, maddr address.Address) (miner.MinerInfo, error)
+	MinerProvingPeriodStart(ctx context.Context, maddr address.Address) (abi.ChainEpoch, error)
+	MinerFaults(ctx context.Context, maddr address.Address) ([]uint64, error)
+	MinerPledgeCollateral(ctx context.Context, maddr address.Address) (locked abi.TokenAmount, total abi.TokenAmount, err error)
+	MinerGetSector(ctx context.Context, maddr address.Address, sectorNum abi.SectorNumber) (*miner.SectorOnChainInfo, bool, error)
+	MinerSectorsForEach(ctx context.Context, maddr address.Address, f func(abi.SectorNumber, cid.Cid, abi.RegisteredProof, []abi.DealID) error) error
+	PowerMinerAddresses(ctx context.Context) ([]address.Address, error)
+	InitResolveAddress(ctx context.Context, a address.Address) (address.Address, error)
 }
 
 // MinerCreate creates a new miner actor for the given account and returns its address.
@@ -191,11 +201,17 @@ type MinerStatus struct {
 	WindowPoStPartitionSectors uint64
 	SectorCount                uint64
 	PoStFailureCount           int
+	ProvingPeriodStart         abi.ChainEpoch
 
 	RawPower                    abi.StoragePower
 	NetworkRawPower             abi.StoragePower
 	NetworkQualityAdjustedPower abi.StoragePower
 	QualityAdjustedPower        abi.StoragePower
+
+	// Collateral is the balance locked as pledge collateral and pre-commit deposits.
+	Collateral abi.TokenAmount
+	// Available is the remainder of the miner actor's balance.
+	Available abi.TokenAmount
 }
 
 // MinerGetStatus queries the power of a given miner.
@@ -220,6 +236,14 @@ func MinerGetStatus(ctx context.Context, plumbing minerStatusPlumbing, minerAddr
 	if err != nil {
 		return MinerStatus{}, err
 	}
+	provingPeriodStart, err := view.MinerProvingPeriodStart(ctx, minerAddr)
+	if err != nil {
+		return MinerStatus{}, err
+	}
+	collateral, balance, err := view.MinerPledgeCollateral(ctx, minerAddr)
+	if err != nil {
+		return MinerStatus{}, err
+	}
 
 	return MinerStatus{
 		ActorAddress:  minerAddr,
@@ -231,14 +255,321 @@ func MinerGetStatus(ctx context.Context, plumbing minerStatusPlumbing, minerAddr
 		SectorSize:                 minerInfo.SectorSize,
 		WindowPoStPartitionSectors: minerInfo.WindowPoStPartitionSectors,
 		SectorCount:                sectorCount,
+		ProvingPeriodStart:         provingPeriodStart,
 
 		RawPower:                    rawPower,
 		QualityAdjustedPower:        qaPower,
 		NetworkRawPower:             totalPower.RawBytePower,
 		NetworkQualityAdjustedPower: totalPower.QualityAdjustedPower,
+
+		Collateral: collateral,
+		Available:  big.Sub(balance, collateral),
 	}, nil
 }
 
+type minerStatusAtHeightPlumbing interface {
+	chainHeadPlumbing
+	MinerStateView(baseKey block.TipSetKey) (MinerStateView, error)
+}
+
+// MinerGetStatusAtHeight queries the status of a miner as of the tipset at height on the chain
+// ending in the current head. A null round resolves to the tipset before it.
+func MinerGetStatusAtHeight(ctx context.Context, plumbing minerStatusAtHeightPlumbing, minerAddr address.Address, height abi.ChainEpoch) (MinerStatus, error) {
+	ts, err := ChainTipSetAtHeight(ctx, plumbing, height)
+	if err != nil {
+		return MinerStatus{}, err
+	}
+	return MinerGetStatus(ctx, plumbing, minerAddr, ts.Key())
+}
+
+// ProvingPeriodPosition summarizes where the chain height falls relative to a miner's proving period.
+// It is derived from heights alone and is not a health signal: a miner can be in its period while
+// having faulted sectors or missed PoSts. See MinerGetFaults for those.
+// There is no position past the end of a period: the miner actor's cron starts the next period
+// when one ends, and detects missed PoSts as faults.
+type ProvingPeriodPosition int
+
+const (
+	// ProvingNotStarted means the miner's first proving period has not yet begun
+	ProvingNotStarted = ProvingPeriodPosition(iota)
+
+	// ProvingInPeriod means the chain is within the miner's current proving period
+	ProvingInPeriod
+)
+
+func (p ProvingPeriodPosition) String() string {
+	switch p {
+	case ProvingNotStarted:
+		return "NotStarted"
+	case ProvingInPeriod:
+		return "InPeriod"
+	default:
+		return fmt.Sprintf("ProvingPeriodPosition(%d)", int(p))
+	}
+}
+
+// MinerProvingStatus describes a miner's progress through its current proving period.
+type MinerProvingStatus struct {
+	ChainHeight        abi.ChainEpoch
+	ProvingPeriodStart abi.ChainEpoch
+	// ProvingPeriodEnd is the last epoch of the proving period.
+	ProvingPeriodEnd abi.ChainEpoch
+	// Started is false when the miner's first proving period has not yet begun.
+	Started         bool
+	PeriodPosition  ProvingPeriodPosition
+	PercentComplete float64
+	// EpochsRemaining counts the epochs of the period after the chain height.
+	EpochsRemaining abi.ChainEpoch
+}
+
+// MinerGetProvingStatus computes how far through its proving period a miner is at the given tipset.
+func MinerGetProvingStatus(ctx context.Context, plumbing minerStatusPlumbing, minerAddr address.Address, key block.TipSetKey) (MinerProvingStatus, error) {
+	view, err := plumbing.MinerStateView(key)
+	if err != nil {
+		return MinerProvingStatus{}, err
+	}
+	start, err := view.MinerProvingPeriodStart(ctx, minerAddr)
+	if err != nil {
+		return MinerProvingStatus{}, err
+	}
+	ts, err := plumbing.ChainTipSet(key)
+	if err != nil {
+		return MinerProvingStatus{}, err
+	}
+	height, err := ts.Height()
+	if err != nil {
+		return MinerProvingStatus{}, err
+	}
+
+	// The miner actor's cron advances the proving period start during the last epoch of each
+	// period, so the state after that epoch already holds the next period's start. The parent
+	// state still holds the start of the period that is ending, or the same start if the miner's
+	// first period has yet to begin.
+	if height == start-1 {
+		parentKey, err := ts.Parents()
+		if err != nil {
+			return MinerProvingStatus{}, err
+		}
+		if !parentKey.Empty() {
+			parentView, err := plumbing.MinerStateView(parentKey)
+			if err != nil {
+				return MinerProvingStatus{}, err
+			}
+			// A miner created in this tipset has no earlier period.
+			if parentStart, err := parentView.MinerProvingPeriodStart(ctx, minerAddr); err == nil {
+				start = parentStart
+			}
+		}
+	}
+
+	deadline := miner.ComputeProvingPeriodDeadline(start, height)
+	status := MinerProvingStatus{
+		ChainHeight:        height,
+		ProvingPeriodStart: deadline.PeriodStart,
+		ProvingPeriodEnd:   deadline.PeriodEnd(),
+		Started:            deadline.PeriodStarted(),
+	}
+	if !status.Started {
+		status.PeriodPosition = ProvingNotStarted
+		status.EpochsRemaining = miner.WPoStProvingPeriod
+		return status, nil
+	}
+	status.PeriodPosition = ProvingInPeriod
+
+	// The epoch at the chain height counts as elapsed, so the last epoch of a period is 100%.
+	elapsed := height - deadline.PeriodStart + 1
+	status.PercentComplete = 100 * float64(elapsed) / float64(miner.WPoStProvingPeriod)
+	status.EpochsRemaining = miner.WPoStProvingPeriod - elapsed
+	return status, nil
+}
+
+// MinerWatchEvent reports a change to a miner's proving period or committed sectors.
+type MinerWatchEvent struct {
+	ChainHeight        abi.ChainEpoch
+	ProvingPeriodStart abi.ChainEpoch
+	SectorCount        uint64
+	// PeriodAdvanced is true when the proving period start moved since the previous observation.
+	PeriodAdvanced bool
+	// NewSectors is the number of sectors committed since the previous observation.
+	NewSectors uint64
+}
+
+// MinerWatchChange compares two observations of a miner's status and returns an event
+// describing the change, or false if neither the proving period nor the sector count advanced.
+func MinerWatchChange(height abi.ChainEpoch, prev, cur MinerStatus) (MinerWatchEvent, bool) {
+	event := MinerWatchEvent{
+		ChainHeight:        height,
+		ProvingPeriodStart: cur.ProvingPeriodStart,
+		SectorCount:        cur.SectorCount,
+		PeriodAdvanced:     cur.ProvingPeriodStart > prev.ProvingPeriodStart,
+	}
+	if cur.SectorCount > prev.SectorCount {
+		event.NewSectors = cur.SectorCount - prev.SectorCount
+	}
+	return event, event.PeriodAdvanced || event.NewSectors > 0
+}
+
+// MinerList returns the addresses of all miners with power claims.
+func MinerList(ctx context.Context, plumbing minerStatusPlumbing, key block.TipSetKey) ([]address.Address, error) {
+	view, err := plumbing.MinerStateView(key)
+	if err != nil {
+		return nil, err
+	}
+	return view.PowerMinerAddresses(ctx)
+}
+
+// MinerListByOwner returns the addresses of all miners owned by the given address.
+func MinerListByOwner(ctx context.Context, plumbing minerStatusPlumbing, owner address.Address, key block.TipSetKey) ([]address.Address, error) {
+	view, err := plumbing.MinerStateView(key)
+	if err != nil {
+		return nil, err
+	}
+	// miner owners are stored as ID addresses
+	ownerID, err := view.InitResolveAddress(ctx, owner)
+	if err == initact.ErrAddressNotFound {
+		// a key address that has never received a message has no actor, so it owns no miners
+		return []address.Address{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	miners, err := view.PowerMinerAddresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	owned := []address.Address{}
+	for _, minerAddr := range miners {
+		minerOwner, _, err := view.MinerControlAddresses(ctx, minerAddr)
+		if err != nil {
+			return nil, err
+		}
+		if minerOwner == ownerID {
+			owned = append(owned, minerAddr)
+		}
+	}
+	return owned, nil
+}
+
+// MinerSectorSummary identifies a committed sector by number and sealed CID.
+type MinerSectorSummary struct {
+	SectorNumber    abi.SectorNumber
+	SealedCID       cid.Cid
+	RegisteredProof abi.RegisteredProof
+	DealIDs         []abi.DealID
+}
+
+// MinerDump is a snapshot of a miner's on-chain state.
+type MinerDump struct {
+	Status        MinerStatus
+	ProvingStatus MinerProvingStatus
+	Faults        []abi.SectorNumber
+	Sectors       []MinerSectorSummary
+}
+
+// MinerGetDump collects a miner's status, proving progress, faults and committed sectors at the given tipset.
+func MinerGetDump(ctx context.Context, plumbing minerStatusPlumbing, minerAddr address.Address, key block.TipSetKey) (MinerDump, error) {
+	status, err := MinerGetStatus(ctx, plumbing, minerAddr, key)
+	if err != nil {
+		return MinerDump{}, err
+	}
+	provingStatus, err := MinerGetProvingStatus(ctx, plumbing, minerAddr, key)
+	if err != nil {
+		return MinerDump{}, err
+	}
+	faults, err := MinerGetFaults(ctx, plumbing, minerAddr, key)
+	if err != nil {
+		return MinerDump{}, err
+	}
+
+	view, err := plumbing.MinerStateView(key)
+	if err != nil {
+		return MinerDump{}, err
+	}
+	sectors := []MinerSectorSummary{}
+	err = view.MinerSectorsForEach(ctx, minerAddr, func(num abi.SectorNumber, sealedCID cid.Cid, proof abi.RegisteredProof, dealIDs []abi.DealID) error {
+		sectors = append(sectors, MinerSectorSummary{
+			SectorNumber:    num,
+			SealedCID:       sealedCID,
+			RegisteredProof: proof,
+			DealIDs:         dealIDs,
+		})
+		return nil
+	})
+	if err != nil {
+		return MinerDump{}, err
+	}
+
+	return MinerDump{
+		Status:        status,
+		ProvingStatus: provingStatus,
+		Faults:        faults,
+		Sectors:       sectors,
+	}, nil
+}
+
+// MinerGetFaults returns the numbers of a miner's currently faulted sectors.
+func MinerGetFaults(ctx context.Context, plumbing minerStatusPlumbing, minerAddr address.Address, key block.TipSetKey) ([]abi.SectorNumber, error) {
+	view, err := plumbing.MinerStateView(key)
+	if err != nil {
+		return nil, err
+	}
+	faults, err := view.MinerFaults(ctx, minerAddr)
+	if err != nil {
+		return nil, err
+	}
+	sectors := make([]abi.SectorNumber, len(faults))
+	for i, f := range faults {
+		sectors[i] = abi.SectorNumber(f)
+	}
+	return sectors, nil
+}
+
+// MinerGetProvenSectors returns the numbers of a miner's committed sectors that are not faulted, in ascending order.
+func MinerGetProvenSectors(ctx context.Context, plumbing minerStatusPlumbing, minerAddr address.Address, key block.TipSetKey) ([]abi.SectorNumber, error) {
+	view, err := plumbing.MinerStateView(key)
+	if err != nil {
+		return nil, err
+	}
+	faults, err := MinerGetFaults(ctx, plumbing, minerAddr, key)
+	if err != nil {
+		return nil, err
+	}
+	faulted := make(map[abi.SectorNumber]struct{}, len(faults))
+	for _, f := range faults {
+		faulted[f] = struct{}{}
+	}
+
+	proven := []abi.SectorNumber{}
+	err = view.MinerSectorsForEach(ctx, minerAddr, func(num abi.SectorNumber, _ cid.Cid, _ abi.RegisteredProof, _ []abi.DealID) error {
+		if _, ok := faulted[num]; !ok {
+			proven = append(proven, num)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(proven, func(i, j int) bool { return proven[i] < proven[j] })
+	return proven, nil
+}
+
+// MinerGetSector returns the on-chain info for a single sector of a miner.
+func MinerGetSector(ctx context.Context, plumbing minerStatusPlumbing, minerAddr address.Address, sectorNum abi.SectorNumber, key block.TipSetKey) (*miner.SectorOnChainInfo, error) {
+	view, err := plumbing.MinerStateView(key)
+	if err != nil {
+		return nil, err
+	}
+	info, found, err := view.MinerGetSector(ctx, minerAddr, sectorNum)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errors.Errorf("sector %d not found for miner %s", sectorNum, minerAddr)
+	}
+	return info, nil
+}
+
 // mwapi is the subset of the plumbing.API that MinerSetWorkerAddress use.
 type mwapi interface {
 	ConfigGet(dottedPath string) (interface{}, error)
@@ -9,6 +9,8 @@ import (
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/specs-actors/actors/abi"
 	"github.com/filecoin-project/specs-actors/actors/abi/big"
+	initact "github.com/filecoin-project/specs-actors/actors/builtin/init"
+	"github.com/filecoin-project/specs-actors/actors/builtin/miner"
 	"github.com/filecoin-project/specs-actors/actors/builtin/power"
 	"github.com/filecoin-project/specs-actors/actors/runtime/exitcode"
 	"github.com/ipfs/go-cid"
@@ -18,6 +20,7 @@ import (
 	"github.com/filecoin-project/go-filecoin/internal/app/go-filecoin/plumbing/cfg"
 	. "github.com/filecoin-project/go-filecoin/internal/app/go-filecoin/porcelain"
 	"github.com/filecoin-project/go-filecoin/internal/pkg/block"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/chain"
 	"github.com/filecoin-project/go-filecoin/internal/pkg/constants"
 	"github.com/filecoin-project/go-filecoin/internal/pkg/encoding"
 	"github.com/filecoin-project/go-filecoin/internal/pkg/repo"
@@ -137,6 +140,9 @@ func TestMinerCreate(t *testing.T) {
 	})
 }
 
+// mStatusSealedCID is the sealed CID of the single sector committed by the mStatusPlumbing miner.
+var mStatusSealedCID = types.NewCidForTestGetter()()
+
 type mStatusPlumbing struct {
 	ts                   block.TipSet
 	head                 block.TipSetKey
@@ -161,10 +167,17 @@ func (p *mStatusPlumbing) MinerStateView(baseKey block.TipSetKey) (MinerStateVie
 		},
 		Miners: map[address.Address]*state.FakeMinerState{
 			p.miner: {
-				Owner:           p.owner,
-				Worker:          p.worker,
-				ClaimedRawPower: abi.NewStoragePower(2),
-				ClaimedQAPower:  abi.NewStoragePower(2),
+				Owner:              p.owner,
+				Worker:             p.worker,
+				ClaimedRawPower:    abi.NewStoragePower(2),
+				ClaimedQAPower:     abi.NewStoragePower(2),
+				ProvingPeriodStart: abi.ChainEpoch(42),
+				PledgeRequirement:  abi.NewTokenAmount(30),
+				PledgeBalance:      abi.NewTokenAmount(100),
+				Faults:             []uint64{3, 7},
+				Sectors: []miner.SectorOnChainInfo{{
+					Info: miner.SectorPreCommitInfo{SectorNumber: 5, SealedCID: mStatusSealedCID},
+				}},
 			},
 		},
 	}, nil
@@ -185,6 +198,372 @@ func TestMinerGetStatus(t *testing.T) {
 	assert.Equal(t, plumbing.worker, status.WorkerAddress)
 	assert.Equal(t, "4", status.NetworkQualityAdjustedPower.String())
 	assert.Equal(t, "2", status.QualityAdjustedPower.String())
+	assert.Equal(t, abi.ChainEpoch(42), status.ProvingPeriodStart)
+	assert.Equal(t, abi.NewTokenAmount(30), status.Collateral)
+	assert.Equal(t, abi.NewTokenAmount(70), status.Available)
+}
+
+// powerHistoryPlumbing serves a chain on which a miner's claimed power is ten times the height
+// of the tipset the state is read at.
+type powerHistoryPlumbing struct {
+	chainHeightPlumbing
+	miner address.Address
+}
+
+func (p *powerHistoryPlumbing) MinerStateView(key block.TipSetKey) (MinerStateView, error) {
+	ts, err := p.ChainTipSet(key)
+	if err != nil {
+		return nil, err
+	}
+	height, err := ts.Height()
+	if err != nil {
+		return nil, err
+	}
+	power := abi.NewStoragePower(10 * int64(height))
+	return &state.FakeStateView{
+		Power: &state.NetworkPower{RawBytePower: power, QualityAdjustedPower: power},
+		Miners: map[address.Address]*state.FakeMinerState{
+			p.miner: {
+				ClaimedRawPower:   power,
+				ClaimedQAPower:    power,
+				PledgeRequirement: abi.NewTokenAmount(0),
+				PledgeBalance:     abi.NewTokenAmount(0),
+			},
+		},
+	}, nil
+}
+
+func TestMinerGetStatusAtHeight(t *testing.T) {
+	tf.UnitTest(t)
+	ctx := context.Background()
+	minerAddr := vmaddr.RequireIDAddress(t, 1)
+
+	builder := chain.NewBuilder(t, address.Undef)
+	genesis := builder.NewGenesis()
+	ts1 := builder.AppendOn(genesis, 1)
+	// leave a null round at height 2
+	ts3 := builder.BuildOneOn(ts1, func(b *chain.BlockBuilder) { b.IncHeight(1) })
+	head := builder.AppendOn(ts3, 1)
+	plumbing := &powerHistoryPlumbing{chainHeightPlumbing{builder: builder, head: head.Key()}, minerAddr}
+
+	status, err := MinerGetStatusAtHeight(ctx, plumbing, minerAddr, 1)
+	require.NoError(t, err)
+	assert.Equal(t, abi.NewStoragePower(10), status.RawPower)
+
+	status, err = MinerGetStatusAtHeight(ctx, plumbing, minerAddr, 3)
+	require.NoError(t, err)
+	assert.Equal(t, abi.NewStoragePower(30), status.RawPower)
+
+	// the null round reports the power as of the tipset before it
+	status, err = MinerGetStatusAtHeight(ctx, plumbing, minerAddr, 2)
+	require.NoError(t, err)
+	assert.Equal(t, abi.NewStoragePower(10), status.RawPower)
+
+	_, err = MinerGetStatusAtHeight(ctx, plumbing, minerAddr, 100)
+	assert.Error(t, err)
+}
+
+// cronProvingPlumbing serves a miner whose proving period start moves on the way the miner
+// actor's proving period cron moves it: during the last epoch of each period that has started.
+type cronProvingPlumbing struct {
+	miner      address.Address
+	firstStart abi.ChainEpoch
+	tipsets    map[string]block.TipSet
+}
+
+// at returns the key of a tipset at height, with a parent at the previous height.
+func (p *cronProvingPlumbing) at(t *testing.T, height abi.ChainEpoch) block.TipSetKey {
+	parent, err := block.NewTipSet(&block.Block{Height: height - 1})
+	require.NoError(t, err)
+	ts, err := block.NewTipSet(&block.Block{Height: height, Parents: parent.Key()})
+	require.NoError(t, err)
+	p.tipsets[parent.Key().String()] = parent
+	p.tipsets[ts.Key().String()] = ts
+	return ts.Key()
+}
+
+func (p *cronProvingPlumbing) ChainTipSet(key block.TipSetKey) (block.TipSet, error) {
+	ts, ok := p.tipsets[key.String()]
+	if !ok {
+		return block.UndefTipSet, fmt.Errorf("no tipset %s", key)
+	}
+	return ts, nil
+}
+
+func (p *cronProvingPlumbing) MinerStateView(key block.TipSetKey) (MinerStateView, error) {
+	ts, err := p.ChainTipSet(key)
+	if err != nil {
+		return nil, err
+	}
+	height, err := ts.Height()
+	if err != nil {
+		return nil, err
+	}
+
+	// The cron for a period runs at its last epoch, so after that epoch the start has moved on.
+	start := p.firstStart
+	if height >= start {
+		start += (height - start + 1) / miner.WPoStProvingPeriod * miner.WPoStProvingPeriod
+	}
+	return &state.FakeStateView{
+		Miners: map[address.Address]*state.FakeMinerState{
+			p.miner: {ProvingPeriodStart: start},
+		},
+	}, nil
+}
+
+func TestMinerGetProvingStatus(t *testing.T) {
+	tf.UnitTest(t)
+	minerAddr := vmaddr.RequireIDAddress(t, 1)
+	plumbing := &cronProvingPlumbing{miner: minerAddr, firstStart: 42, tipsets: map[string]block.TipSet{}}
+
+	statusAt := func(height abi.ChainEpoch) MinerProvingStatus {
+		status, err := MinerGetProvingStatus(context.Background(), plumbing, minerAddr, plumbing.at(t, height))
+		require.NoError(t, err)
+		return status
+	}
+
+	t.Run("not started", func(t *testing.T) {
+		for _, height := range []abi.ChainEpoch{10, 41} {
+			status := statusAt(height)
+			assert.False(t, status.Started)
+			assert.Equal(t, abi.ChainEpoch(42), status.ProvingPeriodStart)
+			assert.Equal(t, 0.0, status.PercentComplete)
+			assert.Equal(t, miner.WPoStProvingPeriod, status.EpochsRemaining)
+		}
+	})
+
+	t.Run("mid period", func(t *testing.T) {
+		status := statusAt(42 + miner.WPoStProvingPeriod/4 - 1)
+		assert.True(t, status.Started)
+		assert.Equal(t, abi.ChainEpoch(42), status.ProvingPeriodStart)
+		assert.Equal(t, 42+miner.WPoStProvingPeriod-1, status.ProvingPeriodEnd)
+		assert.Equal(t, 25.0, status.PercentComplete)
+		assert.Equal(t, miner.WPoStProvingPeriod-miner.WPoStProvingPeriod/4, status.EpochsRemaining)
+	})
+
+	t.Run("last epoch after the cron advances the period", func(t *testing.T) {
+		last := 42 + miner.WPoStProvingPeriod - 1
+		view, err := plumbing.MinerStateView(plumbing.at(t, last))
+		require.NoError(t, err)
+		advanced, err := view.MinerProvingPeriodStart(context.Background(), minerAddr)
+		require.NoError(t, err)
+		require.Equal(t, 42+miner.WPoStProvingPeriod, advanced)
+
+		status := statusAt(last)
+		assert.True(t, status.Started)
+		assert.Equal(t, abi.ChainEpoch(42), status.ProvingPeriodStart)
+		assert.Equal(t, last, status.ProvingPeriodEnd)
+		assert.Equal(t, 100.0, status.PercentComplete)
+		assert.Equal(t, abi.ChainEpoch(0), status.EpochsRemaining)
+	})
+
+	t.Run("first epoch of the next period", func(t *testing.T) {
+		status := statusAt(42 + miner.WPoStProvingPeriod)
+		assert.True(t, status.Started)
+		assert.Equal(t, 42+miner.WPoStProvingPeriod, status.ProvingPeriodStart)
+		assert.Equal(t, miner.WPoStProvingPeriod-1, status.EpochsRemaining)
+	})
+
+	t.Run("period position transitions", func(t *testing.T) {
+		next := 42 + miner.WPoStProvingPeriod
+		for height, position := range map[abi.ChainEpoch]ProvingPeriodPosition{
+			41:                                ProvingNotStarted,
+			42:                                ProvingInPeriod,
+			next - 1:                          ProvingInPeriod,
+			next:                              ProvingInPeriod,
+			next + miner.WPoStChallengeWindow: ProvingInPeriod,
+			next + miner.WPoStProvingPeriod:   ProvingInPeriod,
+		} {
+			assert.Equal(t, position, statusAt(height).PeriodPosition, "height %d", height)
+		}
+	})
+}
+
+func TestMinerWatchChange(t *testing.T) {
+	tf.UnitTest(t)
+	prev := MinerStatus{ProvingPeriodStart: 42, SectorCount: 3}
+
+	t.Run("no change produces no event", func(t *testing.T) {
+		_, changed := MinerWatchChange(50, prev, prev)
+		assert.False(t, changed)
+	})
+
+	t.Run("period advance produces one event", func(t *testing.T) {
+		cur := prev
+		cur.ProvingPeriodStart = 42 + miner.WPoStProvingPeriod
+
+		var events []MinerWatchEvent
+		last := prev
+		for _, next := range []MinerStatus{prev, cur, cur} {
+			if event, changed := MinerWatchChange(100, last, next); changed {
+				events = append(events, event)
+			}
+			last = next
+		}
+		require.Len(t, events, 1)
+		assert.True(t, events[0].PeriodAdvanced)
+		assert.Equal(t, uint64(0), events[0].NewSectors)
+		assert.Equal(t, 42+miner.WPoStProvingPeriod, events[0].ProvingPeriodStart)
+	})
+
+	t.Run("new sectors produce an event", func(t *testing.T) {
+		cur := prev
+		cur.SectorCount = prev.SectorCount + 2
+
+		event, changed := MinerWatchChange(100, prev, cur)
+		require.True(t, changed)
+		assert.False(t, event.PeriodAdvanced)
+		assert.Equal(t, uint64(2), event.NewSectors)
+	})
+}
+
+func TestMinerGetDump(t *testing.T) {
+	tf.UnitTest(t)
+	key := block.NewTipSetKey(types.NewCidForTestGetter()())
+	ts, err := block.NewTipSet(&block.Block{Height: 60})
+	require.NoError(t, err)
+
+	plumbing := mStatusPlumbing{
+		ts, key, vmaddr.RequireIDAddress(t, 1), vmaddr.RequireIDAddress(t, 2), vmaddr.RequireIDAddress(t, 3),
+	}
+	dump, err := MinerGetDump(context.Background(), &plumbing, plumbing.miner, key)
+	require.NoError(t, err)
+
+	assert.Equal(t, plumbing.owner, dump.Status.OwnerAddress)
+	assert.Equal(t, abi.ChainEpoch(42), dump.Status.ProvingPeriodStart)
+	assert.True(t, dump.ProvingStatus.Started)
+	assert.Equal(t, abi.ChainEpoch(60), dump.ProvingStatus.ChainHeight)
+	assert.Equal(t, []abi.SectorNumber{3, 7}, dump.Faults)
+	require.Len(t, dump.Sectors, 1)
+	assert.Equal(t, abi.SectorNumber(5), dump.Sectors[0].SectorNumber)
+	assert.Equal(t, mStatusSealedCID, dump.Sectors[0].SealedCID)
+}
+
+func TestMinerGetProvenSectors(t *testing.T) {
+	tf.UnitTest(t)
+	key := block.NewTipSetKey(types.NewCidForTestGetter()())
+	minerAddr := vmaddr.RequireIDAddress(t, 1)
+
+	sectors := []miner.SectorOnChainInfo{}
+	for _, num := range []abi.SectorNumber{9, 3, 5, 7, 1} {
+		sectors = append(sectors, miner.SectorOnChainInfo{Info: miner.SectorPreCommitInfo{SectorNumber: num}})
+	}
+	plumbing := &mListPlumbing{
+		view: &state.FakeStateView{
+			Miners: map[address.Address]*state.FakeMinerState{
+				minerAddr: {Faults: []uint64{3, 7}, Sectors: sectors},
+			},
+		},
+	}
+
+	proven, err := MinerGetProvenSectors(context.Background(), plumbing, minerAddr, key)
+	require.NoError(t, err)
+	assert.Equal(t, []abi.SectorNumber{1, 5, 9}, proven)
+}
+
+func TestMinerListByOwner(t *testing.T) {
+	tf.UnitTest(t)
+	key := block.NewTipSetKey(types.NewCidForTestGetter()())
+	ownerA := vmaddr.RequireIDAddress(t, 100)
+	ownerB := vmaddr.RequireIDAddress(t, 101)
+	minerA1 := vmaddr.RequireIDAddress(t, 1)
+	minerA2 := vmaddr.RequireIDAddress(t, 2)
+	minerB := vmaddr.RequireIDAddress(t, 3)
+
+	plumbing := &mListPlumbing{
+		view: &state.FakeStateView{
+			Miners: map[address.Address]*state.FakeMinerState{
+				minerA1: {Owner: ownerA},
+				minerA2: {Owner: ownerA},
+				minerB:  {Owner: ownerB},
+			},
+		},
+	}
+
+	miners, err := MinerListByOwner(context.Background(), plumbing, ownerA, key)
+	require.NoError(t, err)
+	assert.Equal(t, []address.Address{minerA1, minerA2}, miners)
+
+	miners, err = MinerListByOwner(context.Background(), plumbing, ownerB, key)
+	require.NoError(t, err)
+	assert.Equal(t, []address.Address{minerB}, miners)
+
+	miners, err = MinerListByOwner(context.Background(), plumbing, vmaddr.RequireIDAddress(t, 102), key)
+	require.NoError(t, err)
+	assert.Empty(t, miners)
+
+	// a key address with no actor behind it owns nothing
+	plumbing.view = &unresolvedKeysView{plumbing.view}
+	miners, err = MinerListByOwner(context.Background(), plumbing, vmaddr.NewForTestGetter()(), key)
+	require.NoError(t, err)
+	assert.NotNil(t, miners)
+	assert.Empty(t, miners)
+}
+
+type mListPlumbing struct {
+	view MinerStateView
+}
+
+// unresolvedKeysView fails to resolve key addresses the way the init actor does for
+// addresses it has no ID mapping for.
+type unresolvedKeysView struct {
+	MinerStateView
+}
+
+func (v *unresolvedKeysView) InitResolveAddress(ctx context.Context, a address.Address) (address.Address, error) {
+	if a.Protocol() != address.ID {
+		return address.Undef, initact.ErrAddressNotFound
+	}
+	return v.MinerStateView.InitResolveAddress(ctx, a)
+}
+
+func (p *mListPlumbing) ChainTipSet(_ block.TipSetKey) (block.TipSet, error) {
+	return block.UndefTipSet, nil
+}
+
+func (p *mListPlumbing) MinerStateView(_ block.TipSetKey) (MinerStateView, error) {
+	return p.view, nil
+}
+
+func TestMinerGetFaults(t *testing.T) {
+	tf.UnitTest(t)
+	key := block.NewTipSetKey(types.NewCidForTestGetter()())
+	ts, err := block.NewTipSet(&block.Block{})
+	require.NoError(t, err)
+
+	plumbing := mStatusPlumbing{
+		ts, key, vmaddr.RequireIDAddress(t, 1), vmaddr.RequireIDAddress(t, 2), vmaddr.RequireIDAddress(t, 3),
+	}
+	faults, err := MinerGetFaults(context.Background(), &plumbing, plumbing.miner, key)
+	assert.NoError(t, err)
+	assert.Equal(t, []abi.SectorNumber{3, 7}, faults)
+
+	_, err = MinerGetFaults(context.Background(), &plumbing, vmaddr.RequireIDAddress(t, 4), key)
+	assert.Error(t, err)
+}
+
+func TestMinerGetSector(t *testing.T) {
+	tf.UnitTest(t)
+	key := block.NewTipSetKey(types.NewCidForTestGetter()())
+	ts, err := block.NewTipSet(&block.Block{})
+	require.NoError(t, err)
+
+	plumbing := mStatusPlumbing{
+		ts, key, vmaddr.RequireIDAddress(t, 1), vmaddr.RequireIDAddress(t, 2), vmaddr.RequireIDAddress(t, 3),
+	}
+
+	t.Run("returns a committed sector", func(t *testing.T) {
+		info, err := MinerGetSector(context.Background(), &plumbing, plumbing.miner, abi.SectorNumber(5), key)
+		require.NoError(t, err)
+		assert.Equal(t, abi.SectorNumber(5), info.Info.SectorNumber)
+		assert.Equal(t, mStatusSealedCID, info.Info.SealedCID)
+	})
+
+	t.Run("errors on an absent sector", func(t *testing.T) {
+		_, err := MinerGetSector(context.Background(), &plumbing, plumbing.miner, abi.SectorNumber(6), key)
+		assert.Error(t, err)
+	})
 }
 
 type mSetWorkerPlumbing struct {
@@ -61,6 +61,14 @@ type publisher interface {
 
 var msgSendErrCt = metrics.NewInt64Counter("message_sender_error", "Number of errors encountered while sending a message")
 
+// RejectedError is returned by the outbox when it refuses to queue a message, e.g. because
+// the message is malformed, cannot be signed or has an unexpected nonce. Resubmitting the
+// same message cannot succeed. Failures to publish a queued message are instead reported
+// on the publish error channel and may succeed on a later attempt.
+type RejectedError struct {
+	error
+}
+
 // NewOutbox creates a new outbox
 func NewOutbox(signer types.Signer, validator messageValidator, queue *Queue,
 	publisher publisher, policy QueuePolicy, chains chainProvider, actors actorProvider, jw journal.Writer) *Outbox {
@@ -87,7 +95,7 @@ func (ob *Outbox) Send(ctx context.Context, from, to address.Address, value type
 	gasPrice types.AttoFIL, gasLimit gas.Unit, bcast bool, method abi.MethodNum, params interface{}) (out cid.Cid, pubErrCh chan error, err error) {
 	encodedParams, err := encoding.Encode(params)
 	if err != nil {
-		return cid.Undef, nil, errors.Wrap(err, "invalid params")
+		return cid.Undef, nil, &RejectedError{errors.Wrap(err, "invalid params")}
 	}
 
 	return ob.SendEncoded(ctx, from, to, value, gasPrice, gasLimit, bcast, method, encodedParams)
@@ -105,6 +113,9 @@ func (ob *Outbox) SendEncoded(ctx context.Context, from, to address.Address, val
 			"to", to.String(), "from", from.String(), "value", value.Int.Uint64(), "method", method,
 			"gasPrice", gasPrice.Int.Uint64(), "gasLimit", uint64(gasLimit), "bcast", bcast,
 			"encodedParams", encodedParams, "error", err, "cid", out.String())
+		if err != nil {
+			err = &RejectedError{err}
+		}
 	}()
 
 	// The spec's message syntax validation rules restricts empty parameters
@@ -152,6 +163,7 @@ func (ob *Outbox) SignedSend(ctx context.Context, signed *types.SignedMessage, b
 	defer func() {
 		if err != nil {
 			msgSendErrCt.Inc(ctx, 1)
+			err = &RejectedError{err}
 		}
 	}()
 
@@ -172,20 +184,55 @@ func sendSignedMsg(ctx context.Context, ob *Outbox, signed *types.SignedMessage,
 		return cid.Undef, nil, errors.Wrap(err, "failed to add message to outbound queue")
 	}
 
-	var c cid.Cid
-	if signed.Message.From.Protocol() == address.BLS {
-		// drop signature before generating Cid to match cid of message retrieved from block.
-		c, err = signed.Message.Cid()
-	} else {
-		c, err = signed.Cid()
-	}
+	c, err := sentMessageCid(signed)
 	if err != nil {
 		return cid.Undef, nil, err
 	}
+
+	return c, publishAsync(ctx, ob, signed, c, height, bcast), nil
+}
+
+// Republish publishes a message from the outbound queue again, e.g. after an earlier attempt
+// to publish it failed. The message is neither re-signed nor enqueued a second time, so it
+// keeps its nonce.
+func (ob *Outbox) Republish(ctx context.Context, from address.Address, c cid.Cid, bcast bool) (pubErrCh chan error, err error) {
+	defer func() {
+		if err != nil {
+			msgSendErrCt.Inc(ctx, 1)
+			err = &RejectedError{err}
+		}
+	}()
+
+	var signed *types.SignedMessage
+	for _, qm := range ob.queue.List(from) {
+		qc, err := sentMessageCid(qm.Msg)
+		if err != nil {
+			return nil, err
+		}
+		if qc.Equals(c) {
+			signed = qm.Msg
+			break
+		}
+	}
+	if signed == nil {
+		return nil, errors.Errorf("message %s is not in the outbound queue of %s", c, from)
+	}
+
+	height, err := tipsetHeight(ob.chains, ob.chains.GetHead())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get block height")
+	}
+
+	return publishAsync(ctx, ob, signed, c, height, bcast), nil
+}
+
+// publishAsync publishes a signed message in the background. The returned channel receives
+// the result of publishing and is then closed.
+func publishAsync(ctx context.Context, ob *Outbox, signed *types.SignedMessage, c cid.Cid, height abi.ChainEpoch, bcast bool) chan error {
 	pubErrCh := make(chan error)
 
 	go func() {
-		err = ob.publisher.Publish(ctx, signed, height, bcast)
+		err := ob.publisher.Publish(ctx, signed, height, bcast)
 		if err != nil {
 			log.Errorf("error: %s publishing message %s", err, c.String())
 		}
@@ -193,7 +240,16 @@ func sendSignedMsg(ctx context.Context, ob *Outbox, signed *types.SignedMessage,
 		close(pubErrCh)
 	}()
 
-	return c, pubErrCh, nil
+	return pubErrCh
+}
+
+// sentMessageCid returns the cid under which a sent message will appear on chain.
+func sentMessageCid(signed *types.SignedMessage) (cid.Cid, error) {
+	if signed.Message.From.Protocol() == address.BLS {
+		// drop signature before generating Cid to match cid of message retrieved from block.
+		return signed.Message.Cid()
+	}
+	return signed.Cid()
 }
 
 // HandleNewHead maintains the message queue in response to a new head tipset.
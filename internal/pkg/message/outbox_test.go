@@ -10,6 +10,7 @@ import (
 	"github.com/filecoin-project/specs-actors/actors/builtin"
 	"github.com/filecoin-project/specs-actors/actors/util/adt"
 	"github.com/ipfs/go-cid"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -45,9 +46,64 @@ func TestOutbox(t *testing.T) {
 
 		cid, _, err := ob.Send(context.Background(), sender, sender, types.NewAttoFILFromFIL(2), types.NewGasPrice(0), gas.NewGas(0), bcast, builtin.MethodSend, adt.Empty)
 		assert.Errorf(t, err, "for testing")
+		assert.IsType(t, &message.RejectedError{}, err)
 		assert.False(t, cid.Defined())
 	})
 
+	t.Run("publish failure is reported on the channel", func(t *testing.T) {
+		w, _ := types.NewMockSignersAndKeyInfo(1)
+		sender := w.Addresses[0]
+		queue := message.NewQueue()
+		publisher := &message.MockPublisher{ReturnError: errors.New("message pool is full")}
+		provider := message.NewFakeProvider(t)
+
+		head := provider.BuildOneOn(block.UndefTipSet, func(b *chain.BlockBuilder) {
+			b.IncHeight(1000)
+		})
+		actr := actor.NewActor(builtin.AccountActorCodeID, abi.NewTokenAmount(0), cid.Undef)
+		provider.SetHeadAndActor(t, head.Key(), sender, actr)
+
+		ob := message.NewOutbox(w, message.FakeValidator{}, queue, publisher, message.NullPolicy{}, provider, provider, newOutboxTestJournal(t))
+
+		c, pubDone, err := ob.Send(context.Background(), sender, sender, types.ZeroAttoFIL, types.NewGasPrice(0), gas.NewGas(0), true, builtin.MethodSend, adt.Empty)
+		require.NoError(t, err)
+		assert.True(t, c.Defined())
+		assert.Equal(t, publisher.ReturnError, <-pubDone)
+	})
+
+	t.Run("republish publishes the queued message again", func(t *testing.T) {
+		ctx := context.Background()
+		w, _ := types.NewMockSignersAndKeyInfo(1)
+		sender := w.Addresses[0]
+		queue := message.NewQueue()
+		publisher := &message.MockPublisher{ReturnError: errors.New("message pool is full")}
+		provider := message.NewFakeProvider(t)
+
+		head := provider.BuildOneOn(block.UndefTipSet, func(b *chain.BlockBuilder) {
+			b.IncHeight(1000)
+		})
+		actr := actor.NewActor(builtin.AccountActorCodeID, abi.NewTokenAmount(0), cid.Undef)
+		provider.SetHeadAndActor(t, head.Key(), sender, actr)
+
+		ob := message.NewOutbox(w, message.FakeValidator{}, queue, publisher, message.NullPolicy{}, provider, provider, newOutboxTestJournal(t))
+
+		c, pubDone, err := ob.Send(ctx, sender, sender, types.ZeroAttoFIL, types.NewGasPrice(0), gas.NewGas(0), true, builtin.MethodSend, adt.Empty)
+		require.NoError(t, err)
+		require.Error(t, <-pubDone)
+		sent := publisher.Message
+
+		publisher.ReturnError = nil
+		publisher.Message = nil
+		pubDone, err = ob.Republish(ctx, sender, c, true)
+		require.NoError(t, err)
+		assert.NoError(t, <-pubDone)
+		assert.Equal(t, sent, publisher.Message)
+		assert.Len(t, queue.List(sender), 1)
+
+		_, err = ob.Republish(ctx, sender, cid.Undef, true)
+		assert.IsType(t, &message.RejectedError{}, err)
+	})
+
 	t.Run("send message enqueues and calls Publish, but respects bcast flag for broadcasting", func(t *testing.T) {
 		w, _ := types.NewMockSignersAndKeyInfo(1)
 		sender := w.Addresses[0]
@@ -5,6 +5,7 @@ import (
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
 )
 
 // Int64Counter wraps an opencensus int64 measure that is uses as a counter.
@@ -16,12 +17,25 @@ type Int64Counter struct {
 // NewInt64Counter creates a new Int64Counter with demensionless units.
 func NewInt64Counter(name, desc string) *Int64Counter {
 	log.Infof("registering int64 counter: %s - %s", name, desc)
+	return newInt64Counter(name, desc, view.Count())
+}
+
+// NewInt64SumCounter creates a new Int64Counter with demensionless units that
+// sums the values recorded rather than counting recordings. Values are broken
+// down by the given tag keys.
+func NewInt64SumCounter(name, desc string, tagKeys ...tag.Key) *Int64Counter {
+	log.Infof("registering int64 sum counter: %s - %s", name, desc)
+	return newInt64Counter(name, desc, view.Sum(), tagKeys...)
+}
+
+func newInt64Counter(name, desc string, agg *view.Aggregation, tagKeys ...tag.Key) *Int64Counter {
 	iMeasure := stats.Int64(name, desc, stats.UnitDimensionless)
 	iView := &view.View{
 		Name:        name,
 		Measure:     iMeasure,
 		Description: desc,
-		Aggregation: view.Count(),
+		TagKeys:     tagKeys,
+		Aggregation: agg,
 	}
 	if err := view.Register(iView); err != nil {
 		// a panic here indicates a developer error when creating a view.
@@ -1,46 +1,101 @@
 package metrics
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"time"
 
 	oldlogging "github.com/whyrusleeping/go-logging"
 )
 
+// JSONFormatterOptions controls which fields a JSONFormatter writes.
+// The zero value writes every field with RFC3339 nanosecond timestamps.
+type JSONFormatterOptions struct {
+	// TimestampLayout is the time.Format layout used for the timestamp field.
+	TimestampLayout string
+	// OmitCaller drops the file field holding the caller's file and line.
+	OmitCaller bool
+	// StaticFields are added to every record, e.g. a node ID. They cannot
+	// replace or reintroduce the standard fields.
+	StaticFields map[string]string
+}
+
 // JSONFormatter implements go-logging Formatter for JSON encoded logs
 type JSONFormatter struct {
+	opts JSONFormatterOptions
+}
+
+// NewJSONFormatter creates a JSONFormatter writing the fields selected by opts.
+func NewJSONFormatter(opts JSONFormatterOptions) *JSONFormatter {
+	return &JSONFormatter{opts: opts}
 }
 
-type logRecord struct {
-	Timestamp time.Time `json:"timestamp"`
-	Level     string    `json:"level"`
-	System    string    `json:"system"`
-	Message   string    `json:"message"`
-	File      string    `json:"file"`
+// standardJSONFields are the fields a JSONFormatter writes itself. Static fields with
+// these names are dropped, whether or not the formatter writes the field.
+var standardJSONFields = map[string]bool{
+	"timestamp": true,
+	"level":     true,
+	"system":    true,
+	"message":   true,
+	"file":      true,
 }
 
-// Format implements go-logging Formatter
+// Format implements go-logging Formatter. Fields are written in a fixed order: the
+// standard fields first, then any static fields sorted by name.
 func (jf *JSONFormatter) Format(calldepth int, r *oldlogging.Record, w io.Writer) error {
-	var fileLine string
-	if calldepth > 0 {
-		_, file, line, ok := runtime.Caller(calldepth + 1)
-		if !ok {
-			fileLine = "???:0"
-		} else {
-			fileLine = fmt.Sprintf("%s:%d", filepath.Base(file), line)
+	layout := jf.opts.TimestampLayout
+	if layout == "" {
+		layout = time.RFC3339Nano
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	writeField := func(key, value string) {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
 		}
+		// marshaling a string cannot fail
+		k, _ := json.Marshal(key)
+		v, _ := json.Marshal(value)
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
 	}
-	lr := &logRecord{
-		Timestamp: r.Time,
-		Level:     r.Level.String(),
-		System:    r.Module,
-		Message:   r.Message(),
-		File:      fileLine,
+
+	writeField("timestamp", r.Time.Format(layout))
+	writeField("level", r.Level.String())
+	writeField("system", r.Module)
+	writeField("message", r.Message())
+	if !jf.opts.OmitCaller {
+		var fileLine string
+		if calldepth > 0 {
+			_, file, line, ok := runtime.Caller(calldepth + 1)
+			if !ok {
+				fileLine = "???:0"
+			} else {
+				fileLine = fmt.Sprintf("%s:%d", filepath.Base(file), line)
+			}
+		}
+		writeField("file", fileLine)
+	}
+
+	keys := make([]string, 0, len(jf.opts.StaticFields))
+	for k := range jf.opts.StaticFields {
+		if !standardJSONFields[k] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		writeField(k, jf.opts.StaticFields[k])
 	}
-	encoder := json.NewEncoder(w)
-	return encoder.Encode(lr)
+	buf.WriteString("}\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
 }
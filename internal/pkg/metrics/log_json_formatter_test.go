@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	oldlogging "github.com/whyrusleeping/go-logging"
+
+	tf "github.com/filecoin-project/go-filecoin/internal/pkg/testhelpers/testflags"
+)
+
+func TestJSONFormatter(t *testing.T) {
+	tf.UnitTest(t)
+
+	record := &oldlogging.Record{
+		Time:   time.Date(2020, 5, 1, 12, 30, 0, 0, time.UTC),
+		Module: "test",
+		Level:  oldlogging.INFO,
+		Args:   []interface{}{"hello"},
+	}
+	format := func(jf *JSONFormatter) map[string]interface{} {
+		var buf bytes.Buffer
+		require.NoError(t, jf.Format(1, record, &buf))
+		out := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+		return out
+	}
+
+	t.Run("default fields", func(t *testing.T) {
+		out := format(&JSONFormatter{})
+		assert.Equal(t, "2020-05-01T12:30:00Z", out["timestamp"])
+		assert.Equal(t, "INFO", out["level"])
+		assert.Equal(t, "test", out["system"])
+		assert.Equal(t, "hello", out["message"])
+		assert.Contains(t, out, "file")
+	})
+
+	t.Run("omit caller", func(t *testing.T) {
+		out := format(NewJSONFormatter(JSONFormatterOptions{
+			OmitCaller:   true,
+			StaticFields: map[string]string{"file": "static.go:1"},
+		}))
+		assert.NotContains(t, out, "file")
+		assert.Equal(t, "hello", out["message"])
+	})
+
+	t.Run("fields are written in a stable order", func(t *testing.T) {
+		jf := NewJSONFormatter(JSONFormatterOptions{
+			OmitCaller:   true,
+			StaticFields: map[string]string{"zone": "a", "node": "t01000"},
+		})
+		var buf bytes.Buffer
+		require.NoError(t, jf.Format(1, record, &buf))
+		assert.Equal(t, `{"timestamp":"2020-05-01T12:30:00Z","level":"INFO","system":"test","message":"hello","node":"t01000","zone":"a"}`+"\n", buf.String())
+	})
+
+	t.Run("timestamp layout and static fields", func(t *testing.T) {
+		out := format(NewJSONFormatter(JSONFormatterOptions{
+			TimestampLayout: "2006-01-02",
+			StaticFields:    map[string]string{"node": "t01000", "level": "ignored"},
+		}))
+		assert.Equal(t, "2020-05-01", out["timestamp"])
+		assert.Equal(t, "t01000", out["node"])
+		assert.Equal(t, "INFO", out["level"])
+	})
+}
@@ -57,6 +57,11 @@ func (api *API) ListAsks(maddr address.Address) ([]*storagemarket.SignedStorageA
 	return provider.ListAsks(maddr), nil
 }
 
+// QueryAsk queries a storage provider for its current ask
+func (api *API) QueryAsk(ctx context.Context, info *storagemarket.StorageProviderInfo) (*storagemarket.SignedStorageAsk, error) {
+	return api.storage.Client().GetAsk(ctx, *info)
+}
+
 // ProposeStorageDeal proposes a storage deal
 func (api *API) ProposeStorageDeal(
 	ctx context.Context,
@@ -2,6 +2,7 @@ package state
 
 import (
 	"context"
+	"sort"
 
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/specs-actors/actors/abi"
@@ -41,6 +42,7 @@ type FakeMinerState struct {
 	ProvingPeriodStart  abi.ChainEpoch
 	ProvingPeriodEnd    abi.ChainEpoch
 	PoStFailures        int
+	Faults              []uint64
 	Sectors             []miner.SectorOnChainInfo
 	Deadlines           []*abi.BitField
 	ClaimedRawPower     abi.StoragePower
@@ -59,6 +61,11 @@ func (v *FakeStateView) InitNetworkName(_ context.Context) (string, error) {
 	return v.NetworkName, nil
 }
 
+// InitResolveAddress returns the address unchanged, fake miners are keyed by the address callers use.
+func (v *FakeStateView) InitResolveAddress(_ context.Context, a address.Address) (address.Address, error) {
+	return a, nil
+}
+
 // MinerSectorConfiguration reports a miner's sector size.
 func (v *FakeStateView) MinerSectorConfiguration(ctx context.Context, maddr address.Address) (*MinerSectorConfiguration, error) {
 	m, ok := v.Miners[maddr]
@@ -104,6 +111,21 @@ func (v *FakeStateView) MinerGetSector(_ context.Context, maddr address.Address,
 	return nil, false, nil
 }
 
+// MinerSectorsForEach iterates over a fake miner's sectors.
+func (v *FakeStateView) MinerSectorsForEach(_ context.Context, maddr address.Address,
+	f func(abi.SectorNumber, cid.Cid, abi.RegisteredProof, []abi.DealID) error) error {
+	m, ok := v.Miners[maddr]
+	if !ok {
+		return errors.Errorf("no miner %s", maddr)
+	}
+	for _, s := range m.Sectors {
+		if err := f(s.Info.SectorNumber, s.Info.SealedCID, s.Info.RegisteredProof, s.Info.DealIDs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // MinerControlAddresses reports a miner's control addresses.
 func (v *FakeStateView) MinerControlAddresses(_ context.Context, maddr address.Address) (owner, worker address.Address, err error) {
 	m, ok := v.Miners[maddr]
@@ -133,10 +155,38 @@ func (v *FakeStateView) MinerProvingPeriod(ctx context.Context, maddr address.Ad
 	return m.ProvingPeriodStart, m.ProvingPeriodEnd, m.PoStFailures, nil
 }
 
+// MinerProvingPeriodStart reports the start epoch of a miner's current proving period.
+func (v *FakeStateView) MinerProvingPeriodStart(_ context.Context, maddr address.Address) (abi.ChainEpoch, error) {
+	m, ok := v.Miners[maddr]
+	if !ok {
+		return 0, errors.Errorf("no miner %s", maddr)
+	}
+	return m.ProvingPeriodStart, nil
+}
+
+// MinerFaults reports the sector numbers a miner has faulted.
+func (v *FakeStateView) MinerFaults(_ context.Context, maddr address.Address) ([]uint64, error) {
+	m, ok := v.Miners[maddr]
+	if !ok {
+		return nil, errors.Errorf("no miner %s", maddr)
+	}
+	return m.Faults, nil
+}
+
 func (v *FakeStateView) AccountSignerAddress(ctx context.Context, a address.Address) (address.Address, error) {
 	return a, nil
 }
 
+// PowerMinerAddresses returns the addresses of all fake miners, ordered by address.
+func (v *FakeStateView) PowerMinerAddresses(_ context.Context) ([]address.Address, error) {
+	miners := make([]address.Address, 0, len(v.Miners))
+	for minerAddr := range v.Miners {
+		miners = append(miners, minerAddr)
+	}
+	sort.Slice(miners, func(i, j int) bool { return miners[i].String() < miners[j].String() })
+	return miners, nil
+}
+
 func (v *FakeStateView) PowerNetworkTotal(_ context.Context) (*NetworkPower, error) {
 	return v.Power, nil
 }
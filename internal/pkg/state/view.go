@@ -394,6 +394,22 @@ func (v *View) MinerFaults(ctx context.Context, maddr addr.Address) ([]uint64, e
 	return minerState.Faults.All(miner.SectorsMax)
 }
 
+// MinerPledgeCollateral returns the funds a miner has locked as collateral, including
+// pre-commit deposits, and the miner actor's total balance.
+func (v *View) MinerPledgeCollateral(ctx context.Context, maddr addr.Address) (locked abi.TokenAmount, total abi.TokenAmount, err error) {
+	minerState, err := v.loadMinerActor(ctx, maddr)
+	if err != nil {
+		return big.Zero(), big.Zero(), err
+	}
+	locked = big.Add(minerState.LockedFunds, minerState.PreCommitDeposits)
+
+	minerActor, err := v.loadActor(ctx, maddr)
+	if err != nil {
+		return big.Zero(), big.Zero(), err
+	}
+	return locked, minerActor.Balance, nil
+}
+
 // MinerGetPrecommittedSector Looks up info for a miners precommitted sector.
 // NOTE: exposes on-chain structures directly for storage FSM API.
 func (v *View) MinerGetPrecommittedSector(ctx context.Context, maddr addr.Address, sectorNum abi.SectorNumber) (*miner.SectorPreCommitOnChainInfo, bool, error) {
@@ -532,6 +548,30 @@ func (v *View) PowerNetworkTotal(ctx context.Context) (*NetworkPower, error) {
 	}, nil
 }
 
+// PowerMinerAddresses returns the addresses of all miners with a claim registered with the power actor.
+func (v *View) PowerMinerAddresses(ctx context.Context) ([]addr.Address, error) {
+	powerState, err := v.loadPowerActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := v.asMap(ctx, powerState.Claims)
+	if err != nil {
+		return nil, err
+	}
+
+	var miners []addr.Address
+	var claim power.Claim
+	err = claims.ForEach(&claim, func(key string) error {
+		minerAddr, err := addr.NewFromBytes([]byte(key))
+		if err != nil {
+			return err
+		}
+		miners = append(miners, minerAddr)
+		return nil
+	})
+	return miners, err
+}
+
 // Returns the power of a miner's committed sectors.
 func (v *View) MinerClaimedPower(ctx context.Context, miner addr.Address) (raw, qa abi.StoragePower, err error) {
 	minerResolved, err := v.InitResolveAddress(ctx, miner)
@@ -9,6 +9,7 @@ import (
 	"github.com/filecoin-project/specs-actors/actors/abi"
 	"github.com/filecoin-project/specs-actors/actors/abi/big"
 	acrypto "github.com/filecoin-project/specs-actors/actors/crypto"
+	"github.com/pkg/errors"
 
 	"github.com/filecoin-project/go-filecoin/internal/pkg/block"
 	"github.com/filecoin-project/go-filecoin/internal/pkg/consensus"
@@ -98,13 +99,46 @@ func MakeCommitment() []byte {
 // MakeCommitments creates three random commitments for constructing a
 // types.Commitments.
 func MakeCommitments() types.Commitments {
-	comms := types.Commitments{}
-	copy(comms.CommD[:], MakeCommitment()[:])
-	copy(comms.CommR[:], MakeCommitment()[:])
-	copy(comms.CommRStar[:], MakeCommitment()[:])
+	comms, err := NewCommitments(MakeCommitment(), MakeCommitment(), MakeCommitment())
+	if err != nil {
+		panic(err)
+	}
 	return comms
 }
 
+// ErrInvalidCommitmentLength indicates that a commitment did not have types.CommitmentBytesLen bytes.
+var ErrInvalidCommitmentLength = errors.New("invalid commitment length")
+
+// NewCommitments constructs types.Commitments from raw commD, commR and commRStar bytes,
+// returning ErrInvalidCommitmentLength if any of them is not types.CommitmentBytesLen long.
+func NewCommitments(commD, commR, commRStar []byte) (types.Commitments, error) {
+	if err := checkCommitmentLen("commD", commD); err != nil {
+		return types.Commitments{}, err
+	}
+	if err := checkCommitmentLen("commR", commR); err != nil {
+		return types.Commitments{}, err
+	}
+	if err := checkCommitmentLen("commRStar", commRStar); err != nil {
+		return types.Commitments{}, err
+	}
+
+	var d types.CommD
+	var r types.CommR
+	var rStar types.CommRStar
+	copy(d[:], commD)
+	copy(r[:], commR)
+	copy(rStar[:], commRStar)
+
+	return types.Commitments{CommD: &d, CommR: &r, CommRStar: &rStar}, nil
+}
+
+func checkCommitmentLen(name string, comm []byte) error {
+	if uint(len(comm)) != types.CommitmentBytesLen {
+		return errors.Wrapf(ErrInvalidCommitmentLength, "%s has %d bytes, expected %d", name, len(comm), types.CommitmentBytesLen)
+	}
+	return nil
+}
+
 // MakeRandomBytes generates a randomized byte slice of size 'size'
 func MakeRandomBytes(size int) []byte {
 	comm := make([]byte, size)
@@ -0,0 +1,46 @@
+package testhelpers_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/filecoin-project/go-filecoin/internal/pkg/testhelpers"
+	tf "github.com/filecoin-project/go-filecoin/internal/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/types"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/util/convert"
+)
+
+func TestNewCommitments(t *testing.T) {
+	tf.UnitTest(t)
+
+	valid := make([]byte, types.CommitmentBytesLen)
+
+	t.Run("copies valid commitments", func(t *testing.T) {
+		commD := convert.To32ByteArray([]byte{0xa})
+		commR := convert.To32ByteArray([]byte{0xf})
+		commRStar := convert.To32ByteArray([]byte{0xc})
+
+		comms, err := NewCommitments(commD[:], commR[:], commRStar[:])
+		require.NoError(t, err)
+		assert.Equal(t, types.CommD(commD), *comms.CommD)
+		assert.Equal(t, types.CommR(commR), *comms.CommR)
+		assert.Equal(t, types.CommRStar(commRStar), *comms.CommRStar)
+	})
+
+	t.Run("rejects short and long commitments", func(t *testing.T) {
+		short := make([]byte, types.CommitmentBytesLen-1)
+		long := make([]byte, types.CommitmentBytesLen+1)
+
+		_, err := NewCommitments(short, valid, valid)
+		assert.Equal(t, ErrInvalidCommitmentLength, errors.Cause(err))
+
+		_, err = NewCommitments(valid, long, valid)
+		assert.Equal(t, ErrInvalidCommitmentLength, errors.Cause(err))
+
+		_, err = NewCommitments(valid, valid, nil)
+		assert.Equal(t, ErrInvalidCommitmentLength, errors.Cause(err))
+	})
+}
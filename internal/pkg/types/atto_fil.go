@@ -81,6 +81,47 @@ func NewAttoFILFromFILString(s string) (AttoFIL, bool) {
 	return NewAttoFILFromString(intPart+decPart, 10)
 }
 
+// AttoFILToFILString returns the exact decimal representation of a in filecoin,
+// e.g. "1.5" for 1500000000000000000 attofilecoin. It is the inverse of NewAttoFILFromFILString.
+func AttoFILToFILString(a AttoFIL) string {
+	return formatFIL(a, attoPower)
+}
+
+// FormatFIL formats a as a decimal quantity of filecoin truncated to at most precision
+// decimal places and suffixed with the FIL unit, e.g. "0.0013 FIL".
+func FormatFIL(a AttoFIL, precision int) string {
+	return formatFIL(a, precision) + " FIL"
+}
+
+func formatFIL(a AttoFIL, precision int) string {
+	if a.Int == nil {
+		return "0"
+	}
+	if precision < 0 {
+		precision = 0
+	}
+
+	abs := new(big.Int).Abs(a.Int)
+	intPart, decPart := new(big.Int).QuoRem(abs, tenToTheEighteen.Int, new(big.Int))
+
+	// left pad the decimal part to 18 digits, then truncate and drop trailing zeros
+	dec := decPart.String()
+	dec = strings.Repeat("0", attoPower-len(dec)) + dec
+	if precision < attoPower {
+		dec = dec[:precision]
+	}
+	dec = strings.TrimRight(dec, "0")
+
+	out := intPart.String()
+	if dec != "" {
+		out += "." + dec
+	}
+	if a.Sign() < 0 && out != "0" {
+		out = "-" + out
+	}
+	return out
+}
+
 // NewAttoFILFromString allocates a new AttoFIL set to the value of s attofilecoin,
 // interpreted in the given base, and returns it and a boolean indicating success.
 func NewAttoFILFromString(s string, base int) (AttoFIL, bool) {
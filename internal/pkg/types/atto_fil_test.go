@@ -309,3 +309,43 @@ func TestNewAttoFILFromFILString(t *testing.T) {
 		assert.False(t, ok)
 	})
 }
+
+func TestAttoFILToFILString(t *testing.T) {
+	tf.UnitTest(t)
+
+	t.Run("formats whole, fractional and large values", func(t *testing.T) {
+		assert.Equal(t, "0", AttoFILToFILString(ZeroAttoFIL))
+		assert.Equal(t, "0", AttoFILToFILString(AttoFIL{}))
+		assert.Equal(t, "123", AttoFILToFILString(NewAttoFILFromFIL(123)))
+		assert.Equal(t, "0.000000000000000001", AttoFILToFILString(specsbig.NewInt(1)))
+		assert.Equal(t, "0.12345", AttoFILToFILString(NewAttoFIL(big.NewInt(123450000000000000))))
+
+		attoFIL, _ := new(big.Int).SetString("912129289198393123456789012345678", 10)
+		assert.Equal(t, "912129289198393.123456789012345678", AttoFILToFILString(NewAttoFIL(attoFIL)))
+	})
+
+	t.Run("formats negative values", func(t *testing.T) {
+		assert.Equal(t, "-1.5", AttoFILToFILString(NewAttoFIL(big.NewInt(-1500000000000000000))))
+		assert.Equal(t, "-0.5", AttoFILToFILString(NewAttoFIL(big.NewInt(-500000000000000000))))
+	})
+
+	t.Run("round trips with NewAttoFILFromFILString", func(t *testing.T) {
+		for _, s := range []string{"0", "1", "0.000000000000000001", "0.5", "912129289198393.123456789012345678", "-0.25"} {
+			attoFIL, ok := NewAttoFILFromFILString(s)
+			require.True(t, ok)
+			assert.Equal(t, s, AttoFILToFILString(attoFIL))
+		}
+	})
+}
+
+func TestFormatFIL(t *testing.T) {
+	tf.UnitTest(t)
+
+	attoFIL, _ := NewAttoFILFromFILString("1.23456789")
+
+	assert.Equal(t, "1.23456789 FIL", FormatFIL(attoFIL, 18))
+	assert.Equal(t, "1.2345 FIL", FormatFIL(attoFIL, 4))
+	assert.Equal(t, "1 FIL", FormatFIL(attoFIL, 0))
+	assert.Equal(t, "0 FIL", FormatFIL(specsbig.NewInt(1), 17))
+	assert.Equal(t, "0.000000000000000001 FIL", FormatFIL(specsbig.NewInt(1), 18))
+}
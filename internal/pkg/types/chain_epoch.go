@@ -0,0 +1,23 @@
+package types
+
+import (
+	"strconv"
+
+	"github.com/filecoin-project/specs-actors/actors/abi"
+	"github.com/pkg/errors"
+)
+
+// ParseChainEpoch parses a base 10 block height as accepted on the command line.
+// Leading zeros are allowed; negative and non-numeric values are rejected.
+func ParseChainEpoch(s string) (abi.ChainEpoch, error) {
+	h, err := strconv.ParseUint(s, 10, 63)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid block height %q", s)
+	}
+	return abi.ChainEpoch(h), nil
+}
+
+// ChainEpochString formats a block height in base 10, the inverse of ParseChainEpoch.
+func ChainEpochString(h abi.ChainEpoch) string {
+	return strconv.FormatInt(int64(h), 10)
+}
@@ -0,0 +1,37 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/filecoin-project/specs-actors/actors/abi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/go-filecoin/internal/pkg/testhelpers/testflags"
+	. "github.com/filecoin-project/go-filecoin/internal/pkg/types"
+)
+
+func TestParseChainEpoch(t *testing.T) {
+	tf.UnitTest(t)
+
+	t.Run("round trips", func(t *testing.T) {
+		for _, h := range []abi.ChainEpoch{0, 1, 42, 1 << 40} {
+			parsed, err := ParseChainEpoch(ChainEpochString(h))
+			require.NoError(t, err)
+			assert.Equal(t, h, parsed)
+		}
+	})
+
+	t.Run("accepts leading zeros", func(t *testing.T) {
+		h, err := ParseChainEpoch("00042")
+		require.NoError(t, err)
+		assert.Equal(t, abi.ChainEpoch(42), h)
+	})
+
+	t.Run("rejects invalid heights", func(t *testing.T) {
+		for _, s := range []string{"", "-1", "+1", "1.5", "abc", "0x10", "9223372036854775808"} {
+			_, err := ParseChainEpoch(s)
+			assert.Error(t, err, s)
+		}
+	})
+}
@@ -0,0 +1,68 @@
+package dispatch
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/filecoin-project/specs-actors/actors/abi"
+)
+
+// MethodDescriptor describes a single method exported by an actor.
+type MethodDescriptor struct {
+	Method abi.MethodNum
+	Name   string
+	// Param is the type of the method's single parameter.
+	Param reflect.Type
+	// Return is the type of the method's return value, or nil if it returns nothing.
+	Return reflect.Type
+}
+
+// DescribeExports lists every method exported by an actor, ordered by name.
+// It returns an error if any export could not be dispatched, rather than omitting it.
+//
+// Note: This is intended to be used by tools that generate client bindings.
+func DescribeExports(actor Actor) ([]MethodDescriptor, error) {
+	var out []MethodDescriptor
+	for i, entry := range actor.Exports() {
+		if entry == nil {
+			continue
+		}
+		v := reflect.ValueOf(entry)
+		if err := validateArity(v.Type()); err != nil {
+			return nil, fmt.Errorf("%s. method: %d", err, i)
+		}
+
+		desc := MethodDescriptor{
+			Method: abi.MethodNum(i),
+			Name:   methodName(v),
+			Param:  v.Type().In(1),
+		}
+		if v.Type().NumOut() == 1 {
+			desc.Return = v.Type().Out(0)
+		}
+		out = append(out, desc)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Name != out[j].Name {
+			return out[i].Name < out[j].Name
+		}
+		return out[i].Method < out[j].Method
+	})
+	return out, nil
+}
+
+// methodName recovers the name of a method value, e.g. "Constructor" from
+// "github.com/filecoin-project/specs-actors/actors/builtin/miner.Actor.Constructor-fm".
+func methodName(v reflect.Value) string {
+	fn := runtime.FuncForPC(v.Pointer())
+	if fn == nil {
+		return ""
+	}
+	name := fn.Name()
+	name = name[strings.LastIndex(name, ".")+1:]
+	return strings.TrimSuffix(name, "-fm")
+}
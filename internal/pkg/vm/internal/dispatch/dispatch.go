@@ -96,7 +96,7 @@ func (d *actorDispatcher) signature(methodID abi.MethodNum) (*methodSignature, e
 
 	// get method entry
 	methodIdx := (uint64)(methodID)
-	if len(exports) < (int)(methodIdx) {
+	if uint64(len(exports)) <= methodIdx {
 		return nil, fmt.Errorf("Method undefined. method: %d, code: %s", methodID, d.code)
 	}
 	entry := exports[methodIdx]
@@ -105,9 +105,29 @@ func (d *actorDispatcher) signature(methodID abi.MethodNum) (*methodSignature, e
 	}
 
 	ventry := reflect.ValueOf(entry)
+	if err := validateArity(ventry.Type()); err != nil {
+		return nil, fmt.Errorf("%s. method: %d, code: %s", err, methodID, d.code)
+	}
 	return &methodSignature{method: ventry}, nil
 }
 
+// validateArity checks that an exported method can be invoked by `Dispatch`,
+// so that a malformed export fails with an error instead of a reflection panic.
+//
+// Methods must take a context and a single (possibly nil) argument and return at most one value.
+func validateArity(t reflect.Type) error {
+	if t.Kind() != reflect.Func {
+		return fmt.Errorf("Method export is not a function")
+	}
+	if t.NumIn() != 2 {
+		return fmt.Errorf("Method takes %d arguments, expected a context and a single parameter", t.NumIn())
+	}
+	if t.NumOut() > 1 {
+		return fmt.Errorf("Method returns %d values, expected at most one", t.NumOut())
+	}
+	return nil
+}
+
 // Signature implements `Dispatcher`.
 func (d *actorDispatcher) Signature(methodNum abi.MethodNum) (MethodSignature, error) {
 	return d.signature(methodNum)
@@ -0,0 +1,103 @@
+package dispatch
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/filecoin-project/specs-actors/actors/abi"
+	"github.com/filecoin-project/specs-actors/actors/builtin"
+	"github.com/filecoin-project/specs-actors/actors/builtin/miner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/go-filecoin/internal/pkg/testhelpers/testflags"
+)
+
+type arityActor struct {
+	fakeActor
+}
+
+func (a *arityActor) Exports() []interface{} {
+	return []interface{}{
+		0: nil,
+		1: a.simpleMethod,
+		2: a.noParams,
+		3: a.tooManyReturns,
+		4: "not a method",
+	}
+}
+
+func (*arityActor) tooManyReturns(ctx interface{}, params *SimpleParams) (*SimpleReturn, error) {
+	return nil, nil
+}
+
+func TestDispatchSignatureValidation(t *testing.T) {
+	tf.UnitTest(t)
+
+	d := &actorDispatcher{actor: &arityActor{}}
+
+	t.Run("accepts a well formed method", func(t *testing.T) {
+		_, err := d.Signature(abi.MethodNum(1))
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects undefined methods", func(t *testing.T) {
+		_, err := d.Signature(abi.MethodNum(0))
+		assert.Error(t, err)
+
+		_, err = d.Signature(abi.MethodNum(5))
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects methods with the wrong arity", func(t *testing.T) {
+		_, err := d.Signature(abi.MethodNum(2))
+		assert.Error(t, err)
+
+		_, err = d.Signature(abi.MethodNum(3))
+		assert.Error(t, err)
+
+		_, err = d.Signature(abi.MethodNum(4))
+		assert.Error(t, err)
+	})
+
+	t.Run("dispatch fails instead of panicking", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			_, err := d.Dispatch(abi.MethodNum(2), nil, nil)
+			assert.Error(t, err)
+		})
+	})
+}
+
+func TestDescribeExports(t *testing.T) {
+	tf.UnitTest(t)
+
+	t.Run("describes the miner actor by name", func(t *testing.T) {
+		descs, err := DescribeExports(&miner.Actor{})
+		require.NoError(t, err)
+
+		exported := 0
+		for _, entry := range (&miner.Actor{}).Exports() {
+			if entry != nil {
+				exported++
+			}
+		}
+		require.Len(t, descs, exported)
+
+		byName := map[string]MethodDescriptor{}
+		for i, desc := range descs {
+			if i > 0 {
+				assert.True(t, descs[i-1].Name < desc.Name, "%s before %s", descs[i-1].Name, desc.Name)
+			}
+			byName[desc.Name] = desc
+		}
+		assert.Equal(t, builtin.MethodConstructor, byName["Constructor"].Method)
+		assert.Equal(t, builtin.MethodsMiner.ChangePeerID, byName["ChangePeerID"].Method)
+		assert.Equal(t, reflect.TypeOf(&miner.ChangePeerIDParams{}), byName["ChangePeerID"].Param)
+		assert.Equal(t, builtin.MethodsMiner.ProveCommitSector, byName["ProveCommitSector"].Method)
+	})
+
+	t.Run("fails on a malformed export", func(t *testing.T) {
+		_, err := DescribeExports(&arityActor{})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,37 @@
+package vmcontext
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/filecoin-project/specs-actors/actors/abi"
+	"github.com/filecoin-project/specs-actors/actors/builtin"
+	"github.com/ipfs/go-cid"
+	"go.opencensus.io/tag"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/metrics"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/vm/gas"
+)
+
+var (
+	actorKey  = tag.MustNewKey("actor")
+	methodKey = tag.MustNewKey("method")
+
+	methodGasUsed = metrics.NewInt64SumCounter("vm/method_gas_used", "Gas used by actor method invocations, by actor and method number, on every VM run including previews and validation", actorKey, methodKey)
+)
+
+// recordMethodGas adds the gas used by an invocation of a method on an actor with the given code to the gas metrics.
+// The VM cannot tell whether it is applying canonical blocks, so the same invocation is counted again each time
+// its message is previewed, validated, mined or re-applied after a reorg. The metric shows where gas goes, not
+// how much was spent on chain.
+func recordMethodGas(ctx context.Context, code cid.Cid, method abi.MethodNum, used gas.Unit) {
+	ctx, err := tag.New(ctx,
+		tag.Upsert(actorKey, builtin.ActorNameByCode(code)),
+		tag.Upsert(methodKey, strconv.FormatUint(uint64(method), 10)),
+	)
+	if err != nil {
+		vmlog.Warnf("failed to tag gas metrics: %s", err)
+		return
+	}
+	methodGasUsed.Inc(ctx, int64(used))
+}
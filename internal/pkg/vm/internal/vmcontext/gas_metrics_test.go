@@ -0,0 +1,142 @@
+package vmcontext
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	vdriver "github.com/filecoin-project/chain-validation/drivers"
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/specs-actors/actors/abi"
+	"github.com/filecoin-project/specs-actors/actors/abi/big"
+	"github.com/filecoin-project/specs-actors/actors/builtin"
+	init_ "github.com/filecoin-project/specs-actors/actors/builtin/init"
+	"github.com/filecoin-project/specs-actors/actors/builtin/system"
+	specsruntime "github.com/filecoin-project/specs-actors/actors/runtime"
+	"github.com/filecoin-project/specs-actors/actors/util/adt"
+	"github.com/ipfs/go-datastore"
+	blockstore "github.com/ipfs/go-ipfs-blockstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+
+	"github.com/filecoin-project/go-filecoin/internal/pkg/cborutil"
+	tf "github.com/filecoin-project/go-filecoin/internal/pkg/testhelpers/testflags"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/types"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/vm/gas"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/vm/internal/dispatch"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/vm/internal/storage"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/vm/state"
+)
+
+func TestRecordMethodGas(t *testing.T) {
+	tf.BadUnitTestWithSideEffects(t)
+	ctx := context.Background()
+
+	proveCommit := builtin.MethodsMiner.ProveCommitSector
+	minerName := builtin.ActorNameByCode(builtin.StorageMinerActorCodeID)
+	method := strconv.FormatUint(uint64(proveCommit), 10)
+	before := methodGasSum(t, minerName, method)
+
+	recordMethodGas(ctx, builtin.StorageMinerActorCodeID, proveCommit, gas.NewGas(100))
+	recordMethodGas(ctx, builtin.StorageMinerActorCodeID, proveCommit, gas.NewGas(50))
+	recordMethodGas(ctx, builtin.StorageMinerActorCodeID, builtin.MethodsMiner.ChangePeerID, gas.NewGas(7))
+
+	assert.Equal(t, before+150, methodGasSum(t, minerName, method))
+}
+
+func TestMethodGasExcludesNestedSends(t *testing.T) {
+	tf.BadUnitTestWithSideEffects(t)
+
+	relayCode := types.CidFromString(t, "relay-actor")
+	relayName := builtin.ActorNameByCode(relayCode)
+	hashMethod := strconv.FormatUint(uint64(relayMethodHash), 10)
+	relayMethod := strconv.FormatUint(uint64(relayMethodRelay), 10)
+
+	bs := blockstore.NewBlockstore(datastore.NewMapDatastore())
+	vmstrg := storage.NewStorage(bs)
+	actors := dispatch.NewBuilder().
+		Add(builtin.InitActorCodeID, &init_.Actor{}).
+		Add(builtin.SystemActorCodeID, &system.Actor{}).
+		Add(relayCode, &relayActor{}).
+		Build()
+	vm := NewVM(actors, &vmstrg, state.NewState(cborutil.NewIpldStore(bs)), specialSyscallWrapper{vdriver.NewChainValidationSyscalls()})
+	w := &ValidationVMWrapper{vm: &vm}
+
+	emptyMap, err := adt.MakeEmptyMap(vm.ContextStore()).Root()
+	require.NoError(t, err)
+	_, _, err = w.CreateActor(builtin.InitActorCodeID, builtin.InitActorAddr, big.Zero(), init_.ConstructState(emptyMap, "gastest"))
+	require.NoError(t, err)
+	_, _, err = w.CreateActor(builtin.SystemActorCodeID, builtin.SystemActorAddr, big.Zero(), &system.State{})
+	require.NoError(t, err)
+	relayAddr, err := address.NewIDAddress(100)
+	require.NoError(t, err)
+	_, _, err = w.CreateActor(relayCode, relayAddr, big.Zero(), &system.State{})
+	require.NoError(t, err)
+
+	apply := func(method abi.MethodNum) {
+		_, err := vm.ApplyGenesisMessage(builtin.SystemActorAddr, relayAddr, method, big.Zero(), adt.Empty, &fakeRandSrc{})
+		require.NoError(t, err)
+	}
+
+	// the hashing method charges gas of its own
+	before := methodGasSum(t, relayName, hashMethod)
+	apply(relayMethodHash)
+	hashGas := methodGasSum(t, relayName, hashMethod) - before
+	require.True(t, hashGas > 0)
+
+	// relaying to the hashing method is recorded against the hashing method, leaving the relay
+	// with only the charge for invoking it
+	beforeHash, beforeRelay := methodGasSum(t, relayName, hashMethod), methodGasSum(t, relayName, relayMethod)
+	apply(relayMethodRelay)
+	assert.Equal(t, hashGas, methodGasSum(t, relayName, hashMethod)-beforeHash)
+	invocation := vm.pricelist.OnMethodInvocation(big.Zero(), relayMethodRelay)
+	assert.Equal(t, float64(invocation), methodGasSum(t, relayName, relayMethod)-beforeRelay)
+}
+
+const (
+	relayMethodHash  = abi.MethodNum(2)
+	relayMethodRelay = abi.MethodNum(3)
+)
+
+// relayActor is a minimal actor with one method that charges gas and another that only sends
+// to the first.
+type relayActor struct{}
+
+func (a *relayActor) Exports() []interface{} {
+	return []interface{}{
+		relayMethodHash:  a.Hash,
+		relayMethodRelay: a.Relay,
+	}
+}
+
+func (*relayActor) Hash(rt specsruntime.Runtime, _ *adt.EmptyValue) *adt.EmptyValue {
+	rt.ValidateImmediateCallerAcceptAny()
+	rt.Syscalls().HashBlake2b([]byte("gas"))
+	return nil
+}
+
+func (*relayActor) Relay(rt specsruntime.Runtime, _ *adt.EmptyValue) *adt.EmptyValue {
+	rt.ValidateImmediateCallerAcceptAny()
+	_, code := rt.Send(rt.Message().Receiver(), relayMethodHash, adt.Empty, big.Zero())
+	if code.IsError() {
+		rt.Abortf(code, "relayed send failed")
+	}
+	return nil
+}
+
+// methodGasSum returns the total gas recorded for a method on an actor.
+func methodGasSum(t *testing.T, actorName, method string) float64 {
+	rows, err := view.RetrieveData("vm/method_gas_used")
+	require.NoError(t, err)
+	for _, row := range rows {
+		tags := map[string]string{}
+		for _, tg := range row.Tags {
+			tags[tg.Key.Name()] = tg.Value
+		}
+		if tags["actor"] == actorName && tags["method"] == method {
+			return row.Data.(*view.SumData).Value
+		}
+	}
+	return 0
+}
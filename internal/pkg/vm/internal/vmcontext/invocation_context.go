@@ -21,6 +21,7 @@ import (
 	e "github.com/filecoin-project/go-filecoin/internal/pkg/enccid"
 	"github.com/filecoin-project/go-filecoin/internal/pkg/encoding"
 	"github.com/filecoin-project/go-filecoin/internal/pkg/vm/actor"
+	"github.com/filecoin-project/go-filecoin/internal/pkg/vm/gas"
 	"github.com/filecoin-project/go-filecoin/internal/pkg/vm/internal/runtime"
 )
 
@@ -43,6 +44,7 @@ type invocationContext struct {
 	allowSideEffects  bool
 	toActor           *actor.Actor // The receiving actor
 	stateHandle       internalActorStateHandle
+	nestedGas         gas.Unit // Gas consumed by sends made from this invocation
 }
 
 type internalActorStateHandle interface {
@@ -182,6 +184,15 @@ func (ctx *invocationContext) invoke() (ret returnWrapper, errcode exitcode.Exit
 		panic("bad code: sender address MUST be an ID address at invocation time")
 	}
 
+	// gas used by the method, including the charge for invoking it, is recorded whether it returns
+	// or aborts; nested sends are recorded against the methods they invoke
+	gasBefore := ctx.gasTank.gasConsumed
+	defer func() {
+		if ctx.toActor != nil {
+			recordMethodGas(ctx.rt.context, ctx.toActor.Code.Cid, ctx.msg.method, ctx.gasTank.gasConsumed-gasBefore-ctx.nestedGas)
+		}
+	}()
+
 	// 1. charge gas for msg
 	ctx.gasTank.Charge(ctx.rt.pricelist.OnMethodInvocation(ctx.msg.value, ctx.msg.method), "method invocation")
 
@@ -209,6 +220,7 @@ func (ctx *invocationContext) invoke() (ret returnWrapper, errcode exitcode.Exit
 
 	// 5. load target actor code
 	actorImpl := ctx.rt.getActorImpl(ctx.toActor.Code.Cid)
+
 	// 6. create target state handle
 	stateHandle := newActorStateHandle((*stateHandleContext)(ctx))
 	ctx.stateHandle = &stateHandle
@@ -453,6 +465,10 @@ func (ctx *invocationContext) Send(toAddr address.Address, methodNum abi.MethodN
 	newCtx := newInvocationContext(ctx.rt, ctx.topLevel, newMsg, fromActor, ctx.gasTank, ctx.randSource)
 
 	// 2. invoke
+	gasBefore := ctx.gasTank.gasConsumed
+	defer func() {
+		ctx.nestedGas += ctx.gasTank.gasConsumed - gasBefore
+	}()
 	return newCtx.invoke()
 }
 
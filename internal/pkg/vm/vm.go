@@ -48,3 +48,15 @@ type ActorCodeLoader = dispatch.CodeLoader
 
 // ActorMethodSignature wraps a specific method and allows you to encode/decodes input/output bytes into concrete types.
 type ActorMethodSignature = dispatch.MethodSignature
+
+// ExecutableActor is the interface implemented by actors the VM can dispatch to.
+type ExecutableActor = dispatch.Actor
+
+// ActorMethodDescriptor describes a single method exported by an actor.
+type ActorMethodDescriptor = dispatch.MethodDescriptor
+
+// DescribeActorExports lists every method exported by an actor, ordered by name, or fails if
+// any export is malformed. It is intended for tools that generate client bindings.
+func DescribeActorExports(actor ExecutableActor) ([]ActorMethodDescriptor, error) {
+	return dispatch.DescribeExports(actor)
+}
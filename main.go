@@ -2,7 +2,10 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	logging "github.com/ipfs/go-log/v2"
 
@@ -12,14 +15,12 @@ import (
 func main() {
 
 	// set default log level if no flags given
-	var level logging.LogLevel
-	var err error
-	lvl := os.Getenv("GO_FILECOIN_LOG_LEVEL")
-	if lvl == "" {
-		level = logging.LevelInfo
-	} else {
-		level, err = logging.LevelFromString(lvl)
+	level := logging.LevelInfo
+	if lvl := os.Getenv("GO_FILECOIN_LOG_LEVEL"); lvl != "" {
+		var err error
+		level, err = parseLogLevel(lvl)
 		if err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: ignoring GO_FILECOIN_LOG_LEVEL: %s, defaulting to info\n", err) // nolint: errcheck
 			level = logging.LevelInfo
 		}
 	}
@@ -41,3 +42,41 @@ func main() {
 	code, _ := commands.Run(context.Background(), os.Args, os.Stdin, os.Stdout, os.Stderr)
 	os.Exit(code)
 }
+
+// numericLogLevels maps the numeric levels of the go-logging package, which
+// GO_FILECOIN_LOG_LEVEL historically accepted, to their closest equivalents.
+var numericLogLevels = map[int]logging.LogLevel{
+	0: logging.LevelFatal, // CRITICAL
+	1: logging.LevelError, // ERROR
+	2: logging.LevelWarn,  // WARNING
+	3: logging.LevelInfo,  // NOTICE
+	4: logging.LevelInfo,  // INFO
+	5: logging.LevelDebug, // DEBUG
+}
+
+// parseLogLevel accepts a numeric go-logging level or a level name in any case,
+// including the go-logging names WARNING, NOTICE and CRITICAL.
+func parseLogLevel(s string) (logging.LogLevel, error) {
+	if n, err := strconv.Atoi(s); err == nil {
+		level, ok := numericLogLevels[n]
+		if !ok {
+			return logging.LevelInfo, fmt.Errorf("unknown numeric log level %d", n)
+		}
+		return level, nil
+	}
+
+	switch name := strings.ToLower(s); name {
+	case "warning":
+		return logging.LevelWarn, nil
+	case "notice":
+		return logging.LevelInfo, nil
+	case "critical":
+		return logging.LevelFatal, nil
+	default:
+		level, err := logging.LevelFromString(name)
+		if err != nil {
+			return logging.LevelInfo, fmt.Errorf("unknown log level %q", s)
+		}
+		return level, nil
+	}
+}
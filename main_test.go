@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+
+	logging "github.com/ipfs/go-log/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tf "github.com/filecoin-project/go-filecoin/internal/pkg/testhelpers/testflags"
+)
+
+func TestParseLogLevel(t *testing.T) {
+	tf.UnitTest(t)
+
+	for input, expected := range map[string]logging.LogLevel{
+		"DEBUG":   logging.LevelDebug,
+		"INFO":    logging.LevelInfo,
+		"WARNING": logging.LevelWarn,
+		"ERROR":   logging.LevelError,
+		"debug":   logging.LevelDebug,
+		"warn":    logging.LevelWarn,
+		"Info":    logging.LevelInfo,
+		"5":       logging.LevelDebug,
+		"4":       logging.LevelInfo,
+		"3":       logging.LevelInfo,
+		"2":       logging.LevelWarn,
+		"1":       logging.LevelError,
+	} {
+		level, err := parseLogLevel(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, expected, level, input)
+	}
+
+	for _, input := range []string{"INF0", "verbose", "9", "-1"} {
+		level, err := parseLogLevel(input)
+		assert.Error(t, err, input)
+		assert.Equal(t, logging.LevelInfo, level, input)
+	}
+}
@@ -3,11 +3,13 @@ package gengen_test
 import (
 	"context"
 	"io/ioutil"
+	"strconv"
 	"testing"
 
 	"github.com/filecoin-project/specs-actors/actors/builtin"
 	ds "github.com/ipfs/go-datastore"
 	blockstore "github.com/ipfs/go-ipfs-blockstore"
+	"go.opencensus.io/stats/view"
 
 	"github.com/filecoin-project/go-filecoin/internal/pkg/constants"
 	th "github.com/filecoin-project/go-filecoin/internal/pkg/testhelpers"
@@ -85,3 +87,46 @@ func TestGenGenDeterministic(t *testing.T) {
 		}
 	}
 }
+
+func TestGenGenRecordsMinerMethodGas(t *testing.T) {
+	tf.BadUnitTestWithSideEffects(t)
+
+	minerName := builtin.ActorNameByCode(builtin.StorageMinerActorCodeID)
+	constructor := strconv.FormatUint(uint64(builtin.MethodConstructor), 10)
+	before := methodGasSum(t, minerName, constructor)
+
+	cfg := &GenesisCfg{
+		KeysToGen:         1,
+		PreallocatedFunds: []string{"1000000"},
+		Miners: []*CreateStorageMinerConfig{
+			{
+				Owner:         0,
+				SealProofType: constants.DevSealProofType,
+			},
+		},
+		Network: "gfctest",
+		Seed:    defaultSeed,
+		Time:    defaultTime,
+	}
+	_, err := GenGen(context.Background(), cfg, blockstore.NewBlockstore(ds.NewMapDatastore()))
+	require.NoError(t, err)
+
+	// creating the miner runs the miner actor's constructor
+	assert.True(t, methodGasSum(t, minerName, constructor) > before)
+}
+
+// methodGasSum returns the total gas recorded by the VM for a method on an actor.
+func methodGasSum(t *testing.T, actorName, method string) float64 {
+	rows, err := view.RetrieveData("vm/method_gas_used")
+	require.NoError(t, err)
+	for _, row := range rows {
+		tags := map[string]string{}
+		for _, tg := range row.Tags {
+			tags[tg.Key.Name()] = tg.Value
+		}
+		if tags["actor"] == actorName && tags["method"] == method {
+			return row.Data.(*view.SumData).Value
+		}
+	}
+	return 0
+}